@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"ray-tracer/feature"
+)
+
+// clock draws a dot for each hour of a clock face, rotating the 12 o'clock
+// point around the y axis. The canvas looks down the y axis, with 12 o'clock
+// at the top, and the face radius is 3/8 of the canvas size.
+func clock(size, hours int) (*feature.Canvas, error) {
+	if hours <= 0 {
+		return nil, fmt.Errorf("invalid number of hours %d", hours)
+	}
+
+	c, err := feature.NewCanvas(size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	center := float64(size) / 2
+	radius := float64(size) * 3 / 8
+	dot := max(size/100, 1)
+	twelve := feature.NewPoint(0, 0, 1)
+
+	for hour := range hours {
+		q, err := feature.NewQuaternionFromAxisAngle(feature.NewVector(0, 1, 0), float64(hour)*2*math.Pi/float64(hours))
+		if err != nil {
+			return nil, err
+		}
+		p := q.Matrix().MulTuple(twelve)
+
+		x := int(math.Round(center + p.X*radius))
+		y := int(math.Round(center - p.Z*radius))
+		for dy := -dot; dy <= dot; dy++ {
+			for dx := -dot; dx <= dot; dx++ {
+				if dx*dx+dy*dy > dot*dot {
+					continue
+				}
+				// The dots of a small canvas may be partially out of it.
+				_ = c.WritePixel(x+dx, y+dy, feature.ColorWhite)
+			}
+		}
+	}
+
+	return c, nil
+}
+
+func main() {
+	size := flag.Int("size", 400, "canvas width and height, in pixels")
+	hours := flag.Int("hours", 12, "number of hours on the clock face")
+	out := flag.String("o", "clock.ppm", "PPM or PNG output file")
+	flag.Parse()
+
+	c, err := clock(*size, *hours)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to draw the clock: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := c.WriteFile(*out); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestClock(t *testing.T) {
+	c, err := clock(80, 12)
+	if err != nil {
+		t.Fatalf("error drawing the clock: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want feature.Tuple
+	}{
+		{name: "12 o'clock", x: 40, y: 10, want: feature.ColorWhite},
+		{name: "3 o'clock", x: 70, y: 40, want: feature.ColorWhite},
+		{name: "6 o'clock", x: 40, y: 70, want: feature.ColorWhite},
+		{name: "9 o'clock", x: 10, y: 40, want: feature.ColorWhite},
+		{name: "1 o'clock", x: 55, y: 14, want: feature.ColorWhite},
+		{name: "center", x: 40, y: 40, want: feature.ColorBlack},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.Pixel(test.x, test.y)
+			if err != nil {
+				t.Fatalf("%q: error reading the pixel: %v", test.name, err)
+			}
+
+			if !test.want.IsEqual(got) {
+				t.Errorf("%q: wants %v and got %v", test.name, test.want, got)
+			}
+		})
+	}
+
+	if _, err := clock(80, 0); err == nil {
+		t.Error("expected an error without hours but got no error")
+	}
+	if _, err := clock(0, 12); err == nil {
+		t.Error("expected an error for an invalid size but got no error")
+	}
+}
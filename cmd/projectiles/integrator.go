@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"ray-tracer/feature"
+	"sort"
+	"strings"
+)
+
+// acceleration returns the acceleration of a projectile at a position and
+// with a velocity, in units/tick².
+type acceleration func(position, velocity feature.Tuple) feature.Tuple
+
+// integrator advances a projectile by dt ticks, following its acceleration.
+// Only the position and the velocity change.
+type integrator interface {
+	step(proj projectile, accel acceleration, dt float64) projectile
+}
+
+// integrators are the integrators that can be chosen by name.
+var integrators = map[string]integrator{
+	"euler":               euler{},
+	"semi-implicit-euler": semiImplicitEuler{},
+	"verlet":              verlet{},
+	"rk4":                 rk4{},
+}
+
+// integratorNames returns the sorted names of the integrators.
+func integratorNames() string {
+	names := make([]string, 0, len(integrators))
+	for name := range integrators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// newIntegrator returns the integrator with name.
+func newIntegrator(name string) (integrator, error) {
+	i, ok := integrators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown integrator %q, expected one of %s", name, integratorNames())
+	}
+
+	return i, nil
+}
+
+// euler is the explicit Euler method: the position moves with the velocity
+// at the start of the step. It's first order, so it drifts quickly.
+type euler struct{}
+
+func (euler) step(proj projectile, accel acceleration, dt float64) projectile {
+	a := accel(proj.position, proj.velocity)
+
+	proj.position = proj.position.AddUnchecked(proj.velocity.Mul(dt))
+	proj.velocity = proj.velocity.AddUnchecked(a.Mul(dt))
+
+	return proj
+}
+
+// semiImplicitEuler is the semi-implicit (symplectic) Euler method: the
+// position moves with the velocity at the end of the step.
+type semiImplicitEuler struct{}
+
+func (semiImplicitEuler) step(proj projectile, accel acceleration, dt float64) projectile {
+	a := accel(proj.position, proj.velocity)
+	vel := proj.velocity.AddUnchecked(a.Mul(dt))
+
+	proj.position = proj.position.AddUnchecked(vel.Mul(dt))
+	proj.velocity = vel
+
+	return proj
+}
+
+// verlet is the velocity Verlet method. It's second order and exact for a
+// constant acceleration.
+type verlet struct{}
+
+func (verlet) step(proj projectile, accel acceleration, dt float64) projectile {
+	a := accel(proj.position, proj.velocity)
+	pos := proj.position.AddUnchecked(proj.velocity.Mul(dt)).AddUnchecked(a.Mul(dt * dt / 2))
+
+	// The new acceleration may depend on the velocity, so it's estimated
+	// with the velocity moved by the old acceleration.
+	next := accel(pos, proj.velocity.AddUnchecked(a.Mul(dt)))
+
+	proj.position = pos
+	proj.velocity = proj.velocity.AddUnchecked(a.AddUnchecked(next).Mul(dt / 2))
+
+	return proj
+}
+
+// rk4 is the classic fourth order Runge-Kutta method.
+type rk4 struct{}
+
+func (rk4) step(proj projectile, accel acceleration, dt float64) projectile {
+	p1, v1 := proj.position, proj.velocity
+	a1 := accel(p1, v1)
+
+	p2 := p1.AddUnchecked(v1.Mul(dt / 2))
+	v2 := v1.AddUnchecked(a1.Mul(dt / 2))
+	a2 := accel(p2, v2)
+
+	p3 := p1.AddUnchecked(v2.Mul(dt / 2))
+	v3 := v1.AddUnchecked(a2.Mul(dt / 2))
+	a3 := accel(p3, v3)
+
+	p4 := p1.AddUnchecked(v3.Mul(dt))
+	v4 := v1.AddUnchecked(a3.Mul(dt))
+	a4 := accel(p4, v4)
+
+	dp := v1.AddUnchecked(v2.Mul(2)).AddUnchecked(v3.Mul(2)).AddUnchecked(v4)
+	dv := a1.AddUnchecked(a2.Mul(2)).AddUnchecked(a3.Mul(2)).AddUnchecked(a4)
+
+	proj.position = p1.AddUnchecked(dp.Mul(dt / 6))
+	proj.velocity = v1.AddUnchecked(dv.Mul(dt / 6))
+
+	return proj
+}
+
+// analytic returns the exact projectile after t ticks under a constant
+// acceleration, to measure the error of the integrators.
+func analytic(start projectile, accel feature.Tuple, t float64) projectile {
+	return projectile{
+		position: start.position.AddUnchecked(start.velocity.Mul(t)).AddUnchecked(accel.Mul(t * t / 2)),
+		velocity: start.velocity.AddUnchecked(accel.Mul(t)),
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestIntegrators(t *testing.T) {
+	env := environment{
+		gravity: feature.NewVector(0, -0.1, 0),
+		wind:    feature.NewVector(-0.01, 0, 0),
+	}
+	start := projectile{
+		position: feature.NewPoint(0, 1, 0),
+		velocity: feature.NewVector(1, 1, 0),
+		mass:     1,
+	}
+	exact := analytic(start, env.acceleration(start)(start.position, start.velocity), 10)
+
+	tests := []struct {
+		name     string
+		maxError float64
+	}{
+		{
+			name:     "euler",
+			maxError: 0.6,
+		},
+		{
+			name:     "semi-implicit-euler",
+			maxError: 0.6,
+		},
+		{
+			name:     "verlet",
+			maxError: 0.00001,
+		},
+		{
+			name:     "rk4",
+			maxError: 0.00001,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			integ, err := newIntegrator(test.name)
+			if err != nil {
+				t.Fatalf("%q: error creating the integrator: %v", test.name, err)
+			}
+
+			proj := start
+			for range 10 {
+				proj = tick(env, proj, integ, 1)
+			}
+
+			if e := proj.position.SubUnchecked(exact.position).MagnitudeUnchecked(); e > test.maxError {
+				t.Errorf("%q: wants a position error up to %f and got %f", test.name, test.maxError, e)
+			}
+			if !proj.position.IsPoint() || !proj.velocity.IsVector() {
+				t.Errorf("%q: expected a point and a vector but got %v and %v", test.name, proj.position, proj.velocity)
+			}
+		})
+	}
+
+	if _, err := newIntegrator("leapfrog"); err == nil {
+		t.Error("expected an error for an unknown integrator but got no error")
+	}
+}
+
+func TestRK4VelocityDependentAcceleration(t *testing.T) {
+	// With a = -v the velocity decays as v0 * e^-t.
+	drag := func(_, velocity feature.Tuple) feature.Tuple {
+		return velocity.Neg()
+	}
+	proj := projectile{
+		position: feature.NewPoint(0, 0, 0),
+		velocity: feature.NewVector(1, 0, 0),
+	}
+
+	for range 100 {
+		proj = rk4{}.step(proj, drag, 0.01)
+	}
+
+	if want := 0.36788; !feature.NewVector(want, 0, 0).IsEqual(proj.velocity) {
+		t.Errorf("wants velocity %f and got %v", want, proj.velocity)
+	}
+}
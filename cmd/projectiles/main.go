@@ -1,76 +1,204 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"math"
+	"os"
 	"ray-tracer/feature"
+	"slices"
 )
 
 type projectile struct {
 	position feature.Tuple // it has to be a point
 	velocity feature.Tuple // it has to be a vector
+	mass     float64       // it has to be positive
+	drag     float64       // quadratic drag coefficient, 0 disables the drag
+	force    feature.Tuple // it has to be a vector, applied only to this projectile
+	landed   bool
 }
 
 type environment struct {
 	gravity feature.Tuple // it has to be a vector
-	wind    feature.Tuple // it has to be a vector
+	wind    feature.Tuple // it has to be a vector, applied as a force
+	// restitution is the fraction of the vertical speed kept when bouncing
+	// on the ground, where 0 stops the projectile.
+	restitution float64
 }
 
-// tick return a new projectile after one unit of time (tick).
-func tick(env environment, proj projectile) (projectile, error) {
-	var p projectile
+// acceleration returns the acceleration the environment applies to proj:
+// the gravity plus the wind, the projectile own force and the air drag
+// divided by its mass. The drag grows with the square of the speed.
+func (env environment) acceleration(proj projectile) acceleration {
+	return func(_, velocity feature.Tuple) feature.Tuple {
+		force := env.wind.AddUnchecked(proj.force)
+		if proj.drag != 0 {
+			force = force.SubUnchecked(velocity.Mul(proj.drag * velocity.MagnitudeUnchecked()))
+		}
 
-	pos, err := proj.position.Add(proj.velocity)
-	if err != nil {
-		return p, err
+		return env.gravity.AddUnchecked(force.Mul(1 / proj.mass))
+	}
+}
+
+// collide handles a projectile that reached the ground (y = 0) during a step
+// of dt ticks. It bounces back from the ground, keeping a restitution
+// fraction of its vertical speed, or lands when the bounce is too weak to
+// leave the ground for a tick.
+func (env environment) collide(proj projectile, dt float64) projectile {
+	if proj.position.Y > 0 {
+		return proj
+	}
+	if env.restitution == 0 || proj.velocity.Y >= 0 {
+		proj.landed = true
+		return proj
+	}
+
+	// The projectile sped up below the ground, so the impact speed comes
+	// from the energy at the end of the step. Otherwise every bounce would
+	// add energy and the projectile would never rest. The explicit Euler
+	// method still gains energy on each arc, so with large time steps it may
+	// bounce until the tick limit.
+	g := math.Abs(env.gravity.Y)
+	impact := math.Sqrt(math.Max(proj.velocity.Y*proj.velocity.Y-2*g*-proj.position.Y, 0))
+
+	proj.position.Y = 0
+	proj.velocity.Y = impact * env.restitution
+	if proj.velocity.Y <= g*dt {
+		proj.velocity = feature.NewVector(0, 0, 0)
+		proj.landed = true
+	}
+
+	return proj
+}
+
+// tick return a new projectile after dt units of time (ticks).
+func tick(env environment, proj projectile, integ integrator, dt float64) projectile {
+	if proj.landed {
+		return proj
 	}
 
-	vel, err := proj.velocity.Add(env.gravity)
+	return env.collide(integ.step(proj, env.acceleration(proj), dt), dt)
+}
+
+// simulate runs the scenario until every projectile lands, returning the
+// trajectory of each one. It returns an error, with the trajectories so far,
+// if they didn't land after the maximum number of ticks.
+func simulate(s scenario) ([][]projectile, error) {
+	integ, err := newIntegrator(s.Integrator)
 	if err != nil {
-		return p, err
+		return nil, err
 	}
 
-	vel, err = vel.Add(env.wind)
+	projs, err := s.projectiles()
 	if err != nil {
-		return p, err
+		return nil, err
+	}
+	env := s.environment()
+
+	trajectories := make([][]projectile, len(projs))
+	for i, proj := range projs {
+		trajectories[i] = []projectile{proj}
+	}
+
+	for ticks := 0; slices.ContainsFunc(projs, flying); ticks++ {
+		if ticks >= s.MaxTicks {
+			return trajectories, fmt.Errorf("the projectiles didn't land after %d ticks", ticks)
+		}
+
+		for i, proj := range projs {
+			if proj.landed {
+				continue
+			}
+
+			projs[i] = tick(env, proj, integ, s.DT)
+			trajectories[i] = append(trajectories[i], projs[i])
+		}
 	}
 
-	p.position = pos
-	p.velocity = vel
+	return trajectories, nil
+}
 
-	return p, nil
+// summaries returns the summary of each trajectory of the scenario.
+func summaries(s scenario, trajectories [][]projectile) []summary {
+	env := s.environment()
+
+	ss := make([]summary, len(trajectories))
+	for i, trajectory := range trajectories {
+		ss[i] = summarize(i, trajectory, s.DT)
+
+		// The exact trajectory is only known for a constant acceleration
+		// without bounces.
+		start, last := trajectory[0], trajectory[len(trajectory)-1]
+		if start.drag == 0 && env.restitution == 0 {
+			accel := env.acceleration(start)(start.position, start.velocity)
+			exact := analytic(start, accel, ss[i].FlightTime)
+			ss[i].Error = last.position.SubUnchecked(exact.position).MagnitudeUnchecked()
+		}
+	}
+
+	return ss
 }
 
 func main() {
-	// projectile starts one unit above the origin.​
-	// velocity is normalized to 1 unit/tick.​
-	vel, err := feature.NewVector(1, 1, 0).Normalize()
+	format := flag.String("format", "human", "trajectory output format: "+formatNames())
+	plotPath := flag.String("plot", "", "PPM or PNG image file where the trajectories are plotted")
+	plotWidth := flag.Int("plot-width", 900, "plot width, in pixels")
+	plotHeight := flag.Int("plot-height", 550, "plot height, in pixels")
+
+	s, err := parseScenario(flag.CommandLine, os.Args[1:])
 	if err != nil {
-		panic(fmt.Errorf("failed to initialize the velocity: %v", err))
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
 
-	proj := projectile{
-		position: feature.NewPoint(0, 1, 0),
-		velocity: vel,
+	write, ok := formats[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown format %q, expected one of %s\n", *format, formatNames())
+		os.Exit(2)
 	}
 
-	// gravity -0.1 unit/tick, and wind is -0.01 unit/tick.​
-	env := environment{
-		gravity: feature.NewVector(0, -0.1, 0),
-		wind:    feature.NewVector(-0.01, 0, 0),
+	trajectories, simErr := simulate(s)
+
+	if err := write(os.Stdout, records(trajectories, s.DT)); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write the trajectories: %v\n", err)
+		os.Exit(1)
 	}
 
-	for {
-		if proj.position.Y <= 0 {
-			break
-		}
+	// The summary goes to stderr with the machine formats, so their output
+	// can be piped as it is.
+	summaryOut := os.Stderr
+	if *format == "human" {
+		summaryOut = os.Stdout
+		fmt.Println()
+	}
+	if err := writeSummaries(summaryOut, summaries(s, trajectories), s.Integrator); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write the summary: %v\n", err)
+		os.Exit(1)
+	}
 
-		proj, err = tick(env, proj)
+	if *plotPath != "" {
+		c, err := plot(trajectories, *plotWidth, *plotHeight)
 		if err != nil {
-			panic(fmt.Errorf("failed to tick: %v", err))
+			fmt.Fprintf(os.Stderr, "failed to plot the trajectories: %v\n", err)
+			os.Exit(1)
+		}
+		if err := c.WriteFile(*plotPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write the plot: %v\n", err)
+			os.Exit(1)
 		}
+	}
+
+	if simErr != nil {
+		fmt.Fprintln(os.Stderr, simErr)
+		os.Exit(1)
+	}
 
-		fmt.Printf("proj new position: %v\n", proj.position)
+	if *format == "human" {
+		fmt.Println("\nmission accomplished!")
 	}
+}
 
-	fmt.Println("\nmission accomplished!")
+// flying reports whether proj didn't land yet.
+func flying(proj projectile) bool {
+	return !proj.landed
 }
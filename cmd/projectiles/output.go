@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// record is the state of a projectile at a tick, flattened for the CSV and
+// JSON Lines outputs.
+type record struct {
+	Body  int     `json:"body"`
+	Tick  int     `json:"tick"`
+	Time  float64 `json:"time"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Z     float64 `json:"z"`
+	VX    float64 `json:"vx"`
+	VY    float64 `json:"vy"`
+	VZ    float64 `json:"vz"`
+	Speed float64 `json:"speed"`
+}
+
+// records returns the records of the trajectories, taken every dt ticks.
+func records(trajectories [][]projectile, dt float64) []record {
+	var rs []record
+	for body, trajectory := range trajectories {
+		for tick, proj := range trajectory {
+			rs = append(rs, record{
+				Body:  body,
+				Tick:  tick,
+				Time:  float64(tick) * dt,
+				X:     proj.position.X,
+				Y:     proj.position.Y,
+				Z:     proj.position.Z,
+				VX:    proj.velocity.X,
+				VY:    proj.velocity.Y,
+				VZ:    proj.velocity.Z,
+				Speed: proj.velocity.MagnitudeUnchecked(),
+			})
+		}
+	}
+
+	return rs
+}
+
+// summary describes the flight of a projectile.
+type summary struct {
+	Body       int
+	FlightTime float64
+	// Range is the horizontal distance from the start to the last position.
+	Range  float64
+	Apex   float64
+	Landed bool
+	// Error is the distance from the last position to the exact one, or NaN
+	// when the exact trajectory isn't known.
+	Error float64
+}
+
+// summarize returns the summary of the trajectory of body, taken every dt
+// ticks.
+func summarize(body int, trajectory []projectile, dt float64) summary {
+	first, last := trajectory[0], trajectory[len(trajectory)-1]
+	dx, dz := last.position.X-first.position.X, last.position.Z-first.position.Z
+
+	s := summary{
+		Body:       body,
+		FlightTime: float64(len(trajectory)-1) * dt,
+		Range:      math.Hypot(dx, dz),
+		Apex:       first.position.Y,
+		Landed:     last.landed,
+		Error:      math.NaN(),
+	}
+	for _, proj := range trajectory {
+		s.Apex = math.Max(s.Apex, proj.position.Y)
+	}
+
+	return s
+}
+
+// formats are the trajectory outputs that can be chosen by name.
+var formats = map[string]func(w io.Writer, rs []record) error{
+	"human": writeHuman,
+	"csv":   writeCSV,
+	"jsonl": writeJSONLines,
+}
+
+// formatNames returns the sorted names of the formats.
+func formatNames() string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// writeHuman writes a line of text for each record.
+func writeHuman(w io.Writer, rs []record) error {
+	for _, r := range rs {
+		_, err := fmt.Fprintf(w, "proj %d tick %d (t=%g): position (%g, %g, %g), velocity (%g, %g, %g), speed %g\n",
+			r.Body, r.Tick, r.Time, r.X, r.Y, r.Z, r.VX, r.VY, r.VZ, r.Speed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeCSV writes the records as CSV, with a header row.
+func writeCSV(w io.Writer, rs []record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"body", "tick", "time", "x", "y", "z", "vx", "vy", "vz", "speed"}); err != nil {
+		return err
+	}
+
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	for _, r := range rs {
+		row := []string{
+			strconv.Itoa(r.Body), strconv.Itoa(r.Tick), f(r.Time),
+			f(r.X), f(r.Y), f(r.Z),
+			f(r.VX), f(r.VY), f(r.VZ),
+			f(r.Speed),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// writeJSONLines writes a JSON object for each record, one per line.
+func writeJSONLines(w io.Writer, rs []record) error {
+	e := json.NewEncoder(w)
+	for _, r := range rs {
+		if err := e.Encode(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeSummaries writes a line of text for each summary, naming the
+// integrator for the errors.
+func writeSummaries(w io.Writer, summaries []summary, integrator string) error {
+	for _, s := range summaries {
+		line := fmt.Sprintf("proj %d: flight time %g ticks, range %g units, apex height %g units", s.Body, s.FlightTime, s.Range, s.Apex)
+		if !s.Landed {
+			line += ", still flying"
+		}
+		if !math.IsNaN(s.Error) {
+			line += fmt.Sprintf(", %s error %g units", integrator, s.Error)
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
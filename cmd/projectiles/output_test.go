@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"ray-tracer/feature"
+	"strings"
+	"testing"
+)
+
+var trajectory = []projectile{
+	{position: feature.NewPoint(0, 1, 0), velocity: feature.NewVector(3, 4, 0)},
+	{position: feature.NewPoint(3, 5, 4), velocity: feature.NewVector(0, 0, 1)},
+	{position: feature.NewPoint(6, -1, 8), velocity: feature.NewVector(1, -1, 0), landed: true},
+}
+
+func TestWriteTrajectories(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "human",
+			want: "proj 0 tick 0 (t=0): position (0, 1, 0), velocity (3, 4, 0), speed 5\n" +
+				"proj 0 tick 1 (t=0.5): position (3, 5, 4), velocity (0, 0, 1), speed 1\n" +
+				"proj 0 tick 2 (t=1): position (6, -1, 8), velocity (1, -1, 0), speed 1.4142135623730951\n",
+		},
+		{
+			format: "csv",
+			want: "body,tick,time,x,y,z,vx,vy,vz,speed\n" +
+				"0,0,0,0,1,0,3,4,0,5\n" +
+				"0,1,0.5,3,5,4,0,0,1,1\n" +
+				"0,2,1,6,-1,8,1,-1,0,1.4142135623730951\n",
+		},
+		{
+			format: "jsonl",
+			want: `{"body":0,"tick":0,"time":0,"x":0,"y":1,"z":0,"vx":3,"vy":4,"vz":0,"speed":5}` + "\n" +
+				`{"body":0,"tick":1,"time":0.5,"x":3,"y":5,"z":4,"vx":0,"vy":0,"vz":1,"speed":1}` + "\n" +
+				`{"body":0,"tick":2,"time":1,"x":6,"y":-1,"z":8,"vx":1,"vy":-1,"vz":0,"speed":1.4142135623730951}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			if err := formats[test.format](&buf, records([][]projectile{trajectory}, 0.5)); err != nil {
+				t.Fatalf("%q: error writing: %v", test.format, err)
+			}
+
+			if got := buf.String(); got != test.want {
+				t.Errorf("%q: wants\n%s\nand got\n%s", test.format, test.want, got)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	got := summarize(2, trajectory, 0.5)
+
+	want := summary{Body: 2, FlightTime: 1, Range: 10, Apex: 5, Landed: true}
+	if got.Body != want.Body || got.FlightTime != want.FlightTime || got.Range != want.Range || got.Apex != want.Apex || got.Landed != want.Landed {
+		t.Errorf("wants %+v and got %+v", want, got)
+	}
+	if !math.IsNaN(got.Error) {
+		t.Errorf("wants an unknown error and got %f", got.Error)
+	}
+
+	buf := bytes.Buffer{}
+	got.Error = 0.25
+	if err := writeSummaries(&buf, []summary{got}, "rk4"); err != nil {
+		t.Fatalf("error writing the summary: %v", err)
+	}
+	if want := "proj 2: flight time 1 ticks, range 10 units, apex height 5 units, rk4 error 0.25 units\n"; buf.String() != want {
+		t.Errorf("wants %q and got %q", want, buf.String())
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	s := defaultScenario()
+	s.Integrator = "rk4"
+
+	trajectories, err := simulate(s)
+	if err != nil {
+		t.Fatalf("error simulating: %v", err)
+	}
+
+	ss := summaries(s, trajectories)
+	if len(ss) != 1 || !ss[0].Landed || ss[0].Error > 0.00001 {
+		t.Errorf("wants a landed projectile without error and got %+v", ss)
+	}
+
+	s.MaxTicks = 3
+	trajectories, err = simulate(s)
+	if err == nil || !strings.Contains(err.Error(), "didn't land after 3 ticks") {
+		t.Errorf("got error %v, expected the tick limit error", err)
+	}
+	if len(trajectories) != 1 || len(trajectories[0]) != 4 {
+		t.Errorf("wants the trajectory until the tick limit and got %d positions", len(trajectories[0]))
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestAcceleration(t *testing.T) {
+	env := environment{
+		gravity: feature.NewVector(0, -1, 0),
+		wind:    feature.NewVector(2, 0, 0),
+	}
+
+	tests := []struct {
+		name     string
+		proj     projectile
+		velocity feature.Tuple
+		want     feature.Tuple
+	}{
+		{
+			name:     "wind and gravity",
+			proj:     projectile{mass: 1},
+			velocity: feature.NewVector(0, 0, 0),
+			want:     feature.NewVector(2, -1, 0),
+		},
+		{
+			name:     "heavier projectiles feel less wind",
+			proj:     projectile{mass: 4},
+			velocity: feature.NewVector(0, 0, 0),
+			want:     feature.NewVector(0.5, -1, 0),
+		},
+		{
+			name:     "own force",
+			proj:     projectile{mass: 2, force: feature.NewVector(0, 4, 0)},
+			velocity: feature.NewVector(0, 0, 0),
+			want:     feature.NewVector(1, 1, 0),
+		},
+		{
+			name:     "quadratic drag",
+			proj:     projectile{mass: 1, drag: 0.5},
+			velocity: feature.NewVector(0, -2, 0),
+			want:     feature.NewVector(2, 1, 0),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := env.acceleration(test.proj)(feature.NewPoint(0, 1, 0), test.velocity)
+
+			if !test.want.IsEqual(got) {
+				t.Errorf("%q: wants %v and got %v", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestTerminalVelocity(t *testing.T) {
+	// The drag balances the gravity at v = sqrt(g*m/k).
+	env := environment{gravity: feature.NewVector(0, -1, 0)}
+	proj := projectile{
+		position: feature.NewPoint(0, 1000, 0),
+		velocity: feature.NewVector(0, 0, 0),
+		mass:     2,
+		drag:     0.5,
+	}
+
+	for range 1000 {
+		proj = tick(env, proj, rk4{}, 0.1)
+	}
+
+	if want := 2.0; math.Abs(-proj.velocity.Y-want) > 0.00001 {
+		t.Errorf("wants terminal speed %f and got %f", want, -proj.velocity.Y)
+	}
+}
+
+func TestCollide(t *testing.T) {
+	tests := []struct {
+		name        string
+		restitution float64
+		proj        projectile
+		want        projectile
+	}{
+		{
+			name: "above the ground",
+			proj: projectile{
+				position: feature.NewPoint(1, 0.5, 0),
+				velocity: feature.NewVector(1, -1, 0),
+			},
+			want: projectile{
+				position: feature.NewPoint(1, 0.5, 0),
+				velocity: feature.NewVector(1, -1, 0),
+			},
+		},
+		{
+			name: "lands without restitution",
+			proj: projectile{
+				position: feature.NewPoint(1, -0.5, 0),
+				velocity: feature.NewVector(1, -1, 0),
+			},
+			want: projectile{
+				position: feature.NewPoint(1, -0.5, 0),
+				velocity: feature.NewVector(1, -1, 0),
+				landed:   true,
+			},
+		},
+		{
+			name:        "bounces",
+			restitution: 0.5,
+			proj: projectile{
+				position: feature.NewPoint(1, -0.5, 0),
+				velocity: feature.NewVector(1, -2, 0),
+			},
+			want: projectile{
+				position: feature.NewPoint(1, 0, 0),
+				velocity: feature.NewVector(1, math.Sqrt(3.9)/2, 0),
+			},
+		},
+		{
+			name:        "rests after a weak bounce",
+			restitution: 0.5,
+			proj: projectile{
+				position: feature.NewPoint(1, -0.05, 0),
+				velocity: feature.NewVector(1, -0.1, 0),
+			},
+			want: projectile{
+				position: feature.NewPoint(1, 0, 0),
+				velocity: feature.NewVector(0, 0, 0),
+				landed:   true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := environment{
+				gravity:     feature.NewVector(0, -0.1, 0),
+				restitution: test.restitution,
+			}
+
+			got := env.collide(test.proj, 1)
+
+			if !test.want.position.IsEqual(got.position) || !test.want.velocity.IsEqual(got.velocity) || test.want.landed != got.landed {
+				t.Errorf("%q: wants %+v and got %+v", test.name, test.want, got)
+			}
+		})
+	}
+}
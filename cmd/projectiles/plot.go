@@ -0,0 +1,81 @@
+package main
+
+import (
+	"math"
+	"ray-tracer/feature"
+)
+
+const (
+	// plotMargin is the fraction of the smallest canvas size left empty
+	// around the trajectories.
+	plotMargin = 0.05
+	// slowHue and fastHue are the hues, in degrees, of the slowest and the
+	// fastest positions.
+	slowHue = 240
+	fastHue = 0
+)
+
+// plot draws the x and y positions of the trajectories onto a new canvas
+// with width and height sizes. They're scaled to fit, keeping their aspect
+// ratio, with the y axis pointing up. Each position is coloured by its speed,
+// from blue for the slowest to red for the fastest.
+func plot(trajectories [][]projectile, width, height int) (*feature.Canvas, error) {
+	c, err := feature.NewCanvas(width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	minX, minY, minSpeed := math.Inf(1), math.Inf(1), math.Inf(1)
+	maxX, maxY, maxSpeed := math.Inf(-1), math.Inf(-1), math.Inf(-1)
+	for _, trajectory := range trajectories {
+		for _, proj := range trajectory {
+			minX, maxX = math.Min(minX, proj.position.X), math.Max(maxX, proj.position.X)
+			minY, maxY = math.Min(minY, proj.position.Y), math.Max(maxY, proj.position.Y)
+
+			speed := proj.velocity.MagnitudeUnchecked()
+			minSpeed, maxSpeed = math.Min(minSpeed, speed), math.Max(maxSpeed, speed)
+		}
+	}
+	if math.IsInf(minX, 1) {
+		return c, nil
+	}
+
+	margin := plotMargin * float64(min(width, height))
+	areaWidth, areaHeight := float64(width-1)-2*margin, float64(height-1)-2*margin
+
+	// A straight trajectory has no width or no height, so only the other
+	// size limits the scale.
+	scale := math.Inf(1)
+	if maxX > minX {
+		scale = areaWidth / (maxX - minX)
+	}
+	if maxY > minY {
+		scale = math.Min(scale, areaHeight/(maxY-minY))
+	}
+	if math.IsInf(scale, 1) {
+		scale = 1
+	}
+
+	// Center the trajectories in the area left by the scale.
+	offsetX := margin + (areaWidth-(maxX-minX)*scale)/2
+	offsetY := margin + (areaHeight-(maxY-minY)*scale)/2
+
+	for _, trajectory := range trajectories {
+		for _, proj := range trajectory {
+			x := int(math.Round(offsetX + (proj.position.X-minX)*scale))
+			y := height - 1 - int(math.Round(offsetY+(proj.position.Y-minY)*scale))
+
+			f := 0.0
+			if maxSpeed > minSpeed {
+				f = (proj.velocity.MagnitudeUnchecked() - minSpeed) / (maxSpeed - minSpeed)
+			}
+			color := feature.ColorFromHSV(slowHue+(fastHue-slowHue)*f, 1, 1)
+
+			if err := c.WritePixel(x, y, color); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return c, nil
+}
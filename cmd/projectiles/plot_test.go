@@ -0,0 +1,96 @@
+package main
+
+import (
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestPlot(t *testing.T) {
+	slow := feature.NewVector(1, 0, 0)
+	fast := feature.NewVector(2, 0, 0)
+
+	tests := []struct {
+		name         string
+		trajectories [][]projectile
+		width        int
+		height       int
+		want         map[[2]int]feature.Tuple
+	}{
+		{
+			name: "scaled to fit with the y axis up",
+			trajectories: [][]projectile{
+				{
+					{position: feature.NewPoint(0, 0, 0), velocity: slow},
+					{position: feature.NewPoint(10, 10, 0), velocity: fast},
+				},
+			},
+			width:  21,
+			height: 21,
+			want: map[[2]int]feature.Tuple{
+				{1, 19}:  feature.ColorBlue,
+				{19, 1}:  feature.ColorRed,
+				{10, 10}: feature.ColorBlack,
+			},
+		},
+		{
+			name: "centered keeping the aspect ratio",
+			trajectories: [][]projectile{
+				{
+					{position: feature.NewPoint(-5, 2, 0), velocity: slow},
+					{position: feature.NewPoint(5, 2, 0), velocity: slow},
+				},
+			},
+			width:  41,
+			height: 21,
+			want: map[[2]int]feature.Tuple{
+				{1, 10}:  feature.ColorBlue,
+				{39, 10}: feature.ColorBlue,
+			},
+		},
+		{
+			name: "many trajectories",
+			trajectories: [][]projectile{
+				{{position: feature.NewPoint(0, 0, 0), velocity: slow}},
+				{{position: feature.NewPoint(0, 1, 0), velocity: fast}},
+			},
+			width:  21,
+			height: 21,
+			want: map[[2]int]feature.Tuple{
+				{10, 19}: feature.ColorBlue,
+				{10, 1}:  feature.ColorRed,
+			},
+		},
+		{
+			name:         "no trajectories",
+			trajectories: nil,
+			width:        2,
+			height:       2,
+			want: map[[2]int]feature.Tuple{
+				{0, 0}: feature.ColorBlack,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := plot(test.trajectories, test.width, test.height)
+			if err != nil {
+				t.Fatalf("%q: error plotting: %v", test.name, err)
+			}
+
+			for xy, want := range test.want {
+				got, err := c.Pixel(xy[0], xy[1])
+				if err != nil {
+					t.Fatalf("%q: error reading pixel %v: %v", test.name, xy, err)
+				}
+				if !want.IsEqual(got) {
+					t.Errorf("%q: wants pixel %v %v and got %v", test.name, xy, want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := plot(nil, 0, 10); err == nil {
+		t.Error("expected an error for an invalid size but got no error")
+	}
+}
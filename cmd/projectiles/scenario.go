@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// body holds the parameters of one projectile.
+type body struct {
+	Position feature.Point  `json:"position"`
+	Velocity feature.Vector `json:"velocity"`
+	// Speed is the initial speed, in units/tick. The velocity is normalized
+	// and scaled to it, unless it's 0.
+	Speed float64 `json:"speed"`
+	Mass  float64 `json:"mass"`
+	// Drag is the quadratic drag coefficient, which folds the air density,
+	// the drag coefficient and the cross-sectional area.
+	Drag  float64        `json:"drag"`
+	Force feature.Vector `json:"force"`
+}
+
+// scenario holds every parameter of a simulation. It can be read from a
+// JSON or YAML file, where points and vectors are written like
+// "point(0, 1, 0)" or {"type":"vector","x":1,"y":1,"z":0}.
+type scenario struct {
+	body
+	// Bodies simulates many projectiles at once. The fields missing from a
+	// body keep the values of the scenario, so without bodies the scenario
+	// has a single projectile.
+	Bodies      []json.RawMessage `json:"bodies,omitempty"`
+	Gravity     feature.Vector    `json:"gravity"`
+	Wind        feature.Vector    `json:"wind"`
+	Restitution float64           `json:"restitution"`
+	Integrator  string            `json:"integrator"`
+	DT          float64           `json:"dt"`
+	// MaxTicks stops the projectiles that never land.
+	MaxTicks int `json:"max_ticks"`
+}
+
+// defaultScenario is the book scenario: the projectile starts one unit above
+// the origin at 1 unit/tick, with gravity -0.1 unit/tick and wind -0.01
+// unit/tick.
+func defaultScenario() scenario {
+	return scenario{
+		body: body{
+			Position: feature.Pt(0, 1, 0),
+			Velocity: feature.Vec(1, 1, 0),
+			Speed:    1,
+			Mass:     1,
+		},
+		Gravity:    feature.Vec(0, -0.1, 0),
+		Wind:       feature.Vec(-0.01, 0, 0),
+		Integrator: "euler",
+		DT:         1,
+		MaxTicks:   10000,
+	}
+}
+
+// loadScenario reads the JSON or YAML file at path, following the path
+// extension, over the values of s, so the fields missing from the file keep
+// their values.
+func (s *scenario) loadScenario(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if data, err = yamlToJSON(data); err != nil {
+			return fmt.Errorf("failed to read the scenario %s: %w", path, err)
+		}
+	case ".json":
+	default:
+		return fmt.Errorf("failed to read the scenario %s: unknown format, expected .json, .yaml or .yml", path)
+	}
+
+	if err := decodeStrict(data, s); err != nil {
+		return fmt.Errorf("failed to read the scenario %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// yamlToJSON converts a YAML scenario to JSON, so both formats share the
+// same fields and checks.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(v)
+}
+
+// decodeStrict decodes the JSON data into v, rejecting unknown fields.
+func decodeStrict(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+
+	return d.Decode(v)
+}
+
+// validate checks that the scenario can be simulated.
+func (s scenario) validate() error {
+	if _, err := newIntegrator(s.Integrator); err != nil {
+		return err
+	}
+	if !(s.DT > 0) {
+		return errors.New("the time step must be positive")
+	}
+	if s.MaxTicks <= 0 {
+		return errors.New("the maximum number of ticks must be positive")
+	}
+	if !(s.Restitution >= 0 && s.Restitution <= 1) {
+		return errors.New("the restitution must be between 0 and 1")
+	}
+
+	_, err := s.projectiles()
+
+	return err
+}
+
+// projectiles returns the projectiles at the start of the scenario.
+func (s scenario) projectiles() ([]projectile, error) {
+	if len(s.Bodies) == 0 {
+		proj, err := s.body.projectile()
+		if err != nil {
+			return nil, err
+		}
+
+		return []projectile{proj}, nil
+	}
+
+	projs := make([]projectile, len(s.Bodies))
+	for i, data := range s.Bodies {
+		b := s.body
+		if err := decodeStrict(data, &b); err != nil {
+			return nil, fmt.Errorf("failed to read the body %d: %w", i, err)
+		}
+
+		proj, err := b.projectile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid body %d: %w", i, err)
+		}
+		projs[i] = proj
+	}
+
+	return projs, nil
+}
+
+// projectile returns the projectile of the body.
+func (b body) projectile() (projectile, error) {
+	if !(b.Speed >= 0) {
+		return projectile{}, errors.New("the speed can't be negative")
+	}
+	if !(b.Mass > 0) {
+		return projectile{}, errors.New("the mass must be positive")
+	}
+	if !(b.Drag >= 0) {
+		return projectile{}, errors.New("the drag can't be negative")
+	}
+
+	vel := b.Velocity
+	if b.Speed != 0 {
+		n, err := vel.Normalize()
+		if err != nil {
+			return projectile{}, fmt.Errorf("failed to initialize the velocity: %w", err)
+		}
+		vel = n.Mul(b.Speed)
+	}
+
+	return projectile{
+		position: b.Position.Tuple(),
+		velocity: vel.Tuple(),
+		mass:     b.Mass,
+		drag:     b.Drag,
+		force:    b.Force.Tuple(),
+	}, nil
+}
+
+// environment returns the environment of the scenario.
+func (s scenario) environment() environment {
+	return environment{
+		gravity:     s.Gravity.Tuple(),
+		wind:        s.Wind.Tuple(),
+		restitution: s.Restitution,
+	}
+}
+
+// triple is a flag.Value for three numbers written as "x,y,z".
+type triple struct {
+	x, y, z *float64
+}
+
+func (t triple) String() string {
+	if t.x == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%g,%g,%g", *t.x, *t.y, *t.z)
+}
+
+func (t triple) Set(s string) error {
+	fields := strings.Split(s, ",")
+	if len(fields) != 3 {
+		return fmt.Errorf("expected x,y,z but got %q", s)
+	}
+
+	var values [3]float64
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return fmt.Errorf("expected x,y,z but got %q", s)
+		}
+		values[i] = v
+	}
+
+	*t.x, *t.y, *t.z = values[0], values[1], values[2]
+
+	return nil
+}
+
+// parseScenario builds the scenario from the defaults, then the scenario
+// file and last the flags set in args. The body flags also set the values
+// missing from the bodies of the file.
+func parseScenario(fs *flag.FlagSet, args []string) (scenario, error) {
+	var flags scenario
+
+	path := fs.String("scenario", "", "JSON or YAML scenario file, overridden by the other flags")
+	fs.Var(triple{&flags.Position.X, &flags.Position.Y, &flags.Position.Z}, "position", "start position as x,y,z")
+	fs.Var(triple{&flags.Velocity.X, &flags.Velocity.Y, &flags.Velocity.Z}, "velocity", "start velocity as x,y,z")
+	fs.Float64Var(&flags.Speed, "speed", 0, "start speed in units/tick, 0 keeps the velocity magnitude")
+	fs.Float64Var(&flags.Mass, "mass", 0, "projectile mass")
+	fs.Float64Var(&flags.Drag, "drag", 0, "quadratic drag coefficient, 0 disables the drag")
+	fs.Var(triple{&flags.Force.X, &flags.Force.Y, &flags.Force.Z}, "force", "force applied to the projectile as x,y,z")
+	fs.Var(triple{&flags.Gravity.X, &flags.Gravity.Y, &flags.Gravity.Z}, "gravity", "gravity as x,y,z")
+	fs.Var(triple{&flags.Wind.X, &flags.Wind.Y, &flags.Wind.Z}, "wind", "wind force as x,y,z")
+	fs.Float64Var(&flags.Restitution, "restitution", 0, "fraction of the vertical speed kept when bouncing, 0 disables the bounces")
+	fs.StringVar(&flags.Integrator, "integrator", "", "integration method: "+integratorNames())
+	fs.Float64Var(&flags.DT, "dt", 0, "time step, in ticks")
+	fs.IntVar(&flags.MaxTicks, "max-ticks", 0, "maximum number of ticks before giving up")
+
+	if err := fs.Parse(args); err != nil {
+		return scenario{}, err
+	}
+
+	s := defaultScenario()
+	if *path != "" {
+		if err := s.loadScenario(*path); err != nil {
+			return scenario{}, err
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "position":
+			s.Position = flags.Position
+		case "velocity":
+			s.Velocity = flags.Velocity
+		case "speed":
+			s.Speed = flags.Speed
+		case "mass":
+			s.Mass = flags.Mass
+		case "drag":
+			s.Drag = flags.Drag
+		case "force":
+			s.Force = flags.Force
+		case "gravity":
+			s.Gravity = flags.Gravity
+		case "wind":
+			s.Wind = flags.Wind
+		case "restitution":
+			s.Restitution = flags.Restitution
+		case "integrator":
+			s.Integrator = flags.Integrator
+		case "dt":
+			s.DT = flags.DT
+		case "max-ticks":
+			s.MaxTicks = flags.MaxTicks
+		}
+	})
+
+	return s, s.validate()
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseScenario(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "scenario.json")
+	data := `{
+	"position": "point(0, 5, 0)",
+	"velocity": {"type": "vector", "x": 2, "y": 0, "z": 0},
+	"speed": 0,
+	"integrator": "rk4"
+}`
+	if err := os.WriteFile(file, []byte(data), 0o600); err != nil {
+		t.Fatalf("error writing the scenario: %v", err)
+	}
+
+	yamlFile := filepath.Join(dir, "scenario.yml")
+	yamlData := `position: point(0, 5, 0)
+velocity: {type: vector, x: 2, y: 0, z: 0}
+speed: 0
+integrator: rk4
+`
+	if err := os.WriteFile(yamlFile, []byte(yamlData), 0o600); err != nil {
+		t.Fatalf("error writing the scenario: %v", err)
+	}
+
+	text := filepath.Join(dir, "scenario.txt")
+	if err := os.WriteFile(text, []byte(data), 0o600); err != nil {
+		t.Fatalf("error writing the scenario: %v", err)
+	}
+
+	unknown := filepath.Join(dir, "unknown.json")
+	if err := os.WriteFile(unknown, []byte(`{"radius": 2}`), 0o600); err != nil {
+		t.Fatalf("error writing the scenario: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want func(s *scenario)
+		err  string
+	}{
+		{
+			name: "defaults",
+			args: nil,
+			want: func(s *scenario) {},
+		},
+		{
+			name: "flags",
+			args: []string{"-position", "1,2,3", "-wind", "0, 0, 0.5", "-integrator", "verlet", "-max-ticks", "10"},
+			want: func(s *scenario) {
+				s.Position = feature.Pt(1, 2, 3)
+				s.Wind = feature.Vec(0, 0, 0.5)
+				s.Integrator = "verlet"
+				s.MaxTicks = 10
+			},
+		},
+		{
+			name: "file",
+			args: []string{"-scenario", file},
+			want: func(s *scenario) {
+				s.Position = feature.Pt(0, 5, 0)
+				s.Velocity = feature.Vec(2, 0, 0)
+				s.Speed = 0
+				s.Integrator = "rk4"
+			},
+		},
+		{
+			name: "yaml file",
+			args: []string{"-scenario", yamlFile},
+			want: func(s *scenario) {
+				s.Position = feature.Pt(0, 5, 0)
+				s.Velocity = feature.Vec(2, 0, 0)
+				s.Speed = 0
+				s.Integrator = "rk4"
+			},
+		},
+		{
+			name: "flags override the file",
+			args: []string{"-scenario", file, "-integrator", "euler", "-gravity", "0,-9.8,0"},
+			want: func(s *scenario) {
+				s.Position = feature.Pt(0, 5, 0)
+				s.Velocity = feature.Vec(2, 0, 0)
+				s.Speed = 0
+				s.Gravity = feature.Vec(0, -9.8, 0)
+			},
+		},
+		{
+			name: "unknown field",
+			args: []string{"-scenario", unknown},
+			err:  "unknown field",
+		},
+		{
+			name: "unknown format",
+			args: []string{"-scenario", text},
+			err:  "unknown format",
+		},
+		{
+			name: "invalid triple",
+			args: []string{"-velocity", "1,2"},
+			err:  "expected x,y,z",
+		},
+		{
+			name: "invalid integrator",
+			args: []string{"-integrator", "leapfrog"},
+			err:  "unknown integrator",
+		},
+		{
+			name: "invalid max ticks",
+			args: []string{"-max-ticks", "0"},
+			err:  "maximum number of ticks",
+		},
+		{
+			name: "NaN time step",
+			args: []string{"-dt", "NaN"},
+			err:  "time step",
+		},
+		{
+			name: "NaN restitution",
+			args: []string{"-restitution", "NaN"},
+			err:  "restitution",
+		},
+		{
+			name: "NaN mass",
+			args: []string{"-mass", "NaN"},
+			err:  "mass",
+		},
+		{
+			name: "NaN speed",
+			args: []string{"-speed", "NaN"},
+			err:  "speed",
+		},
+		{
+			name: "NaN drag",
+			args: []string{"-drag", "NaN"},
+			err:  "drag",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("projectiles", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+
+			got, err := parseScenario(fs, test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("%q: got error %v, expected error with %q", test.name, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%q: got error %v, expected no error", test.name, err)
+			}
+
+			want := defaultScenario()
+			test.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%q: wants %+v and got %+v", test.name, want, got)
+			}
+		})
+	}
+}
+
+func TestScenarioProjectiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		bodies string
+		want   []projectile
+		err    string
+	}{
+		{
+			name: "single body",
+			want: []projectile{
+				{
+					position: feature.NewPoint(0, 1, 0),
+					velocity: feature.NewVector(6, 8, 0),
+					mass:     2,
+				},
+			},
+		},
+		{
+			name:   "bodies keep the scenario values",
+			bodies: `[{}, {"mass": 5, "drag": 0.1, "force": "vector(0, 1, 0)"}, {"velocity": "vector(0, 1, 0)", "speed": 0}]`,
+			want: []projectile{
+				{
+					position: feature.NewPoint(0, 1, 0),
+					velocity: feature.NewVector(6, 8, 0),
+					mass:     2,
+				},
+				{
+					position: feature.NewPoint(0, 1, 0),
+					velocity: feature.NewVector(6, 8, 0),
+					mass:     5,
+					drag:     0.1,
+					force:    feature.NewVector(0, 1, 0),
+				},
+				{
+					position: feature.NewPoint(0, 1, 0),
+					velocity: feature.NewVector(0, 1, 0),
+					mass:     2,
+				},
+			},
+		},
+		{
+			name:   "zero velocity",
+			bodies: `[{"velocity": "vector(0, 0, 0)"}]`,
+			err:    "failed to initialize the velocity",
+		},
+		{
+			name:   "zero mass",
+			bodies: `[{"mass": 0}]`,
+			err:    "the mass must be positive",
+		},
+		{
+			name:   "unknown field",
+			bodies: `[{"radius": 1}]`,
+			err:    "unknown field",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := defaultScenario()
+			s.Velocity = feature.Vec(3, 4, 0)
+			s.Speed = 10
+			s.Mass = 2
+			if test.bodies != "" {
+				if err := json.Unmarshal([]byte(test.bodies), &s.Bodies); err != nil {
+					t.Fatalf("%q: error reading the bodies: %v", test.name, err)
+				}
+			}
+
+			got, err := s.projectiles()
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("%q: got error %v, expected error with %q", test.name, err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%q: got error %v, expected no error", test.name, err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("%q: wants %+v and got %+v", test.name, test.want, got)
+			}
+		})
+	}
+}
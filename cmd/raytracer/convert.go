@@ -0,0 +1,22 @@
+package main
+
+import (
+	"io"
+	"ray-tracer/feature"
+)
+
+func runConvert(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("convert", "in.ppm out.png", stderr)
+
+	files, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	c, err := feature.ReadFile(files[0])
+	if err != nil {
+		return err
+	}
+
+	return c.WriteFile(files[1])
+}
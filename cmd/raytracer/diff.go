@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"ray-tracer/feature"
+)
+
+// errDifferent is returned by diff when the images differ, so it exits with
+// 1 like diff(1).
+var errDifferent = errors.New("images differ")
+
+func runDiff(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("diff", "[-tolerance 0] [-o diff.png] a.ppm b.ppm", stderr)
+	tolerance := fs.Float64("tolerance", 0, "largest channel or alpha difference still considered equal")
+	out := fs.String("o", "", "PPM or PNG file for the heat map of the differences")
+
+	files, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	a, err := feature.ReadFile(files[0])
+	if err != nil {
+		return err
+	}
+	b, err := feature.ReadFile(files[1])
+	if err != nil {
+		return err
+	}
+
+	cmp, err := feature.Compare(a, b)
+	if err != nil {
+		return fmt.Errorf("%w: %dx%d and %dx%d", err, a.Width(), a.Height(), b.Width(), b.Height())
+	}
+
+	fmt.Fprintf(stdout, "max error:  %g\n", cmp.MaxError)
+	fmt.Fprintf(stdout, "mean error: %g\n", cmp.MeanError)
+	fmt.Fprintf(stdout, "PSNR:       %g dB\n", cmp.PSNR)
+	fmt.Fprintf(stdout, "SSIM:       %g\n", cmp.SSIM)
+	fmt.Fprintf(stdout, "max alpha error: %g\n", cmp.MaxAlphaError)
+
+	if *out != "" {
+		if err := cmp.Diff.WriteFile(*out); err != nil {
+			return err
+		}
+	}
+
+	// Missed rays are transparent, so a change of alpha alone is a
+	// difference too.
+	if cmp.MaxError > *tolerance || cmp.MaxAlphaError > *tolerance {
+		return errDifferent
+	}
+
+	return nil
+}
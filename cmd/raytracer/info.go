@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"ray-tracer/feature"
+)
+
+// stats are the minimum, the mean and the maximum of a series of values.
+type stats struct {
+	min, mean, max float64
+	n              int
+}
+
+func newStats() stats {
+	return stats{min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// add adds v to the series.
+func (s *stats) add(v float64) {
+	s.min = math.Min(s.min, v)
+	s.max = math.Max(s.max, v)
+	s.n++
+	s.mean += (v - s.mean) / float64(s.n)
+}
+
+func (s stats) String() string {
+	return fmt.Sprintf("min %.4g, mean %.4g, max %.4g", s.min, s.mean, s.max)
+}
+
+func runInfo(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("info", "image...", stderr)
+
+	files, err := parseArgs(fs, args, -1)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		c, err := feature.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		channels := [5]stats{newStats(), newStats(), newStats(), newStats(), newStats()}
+		for y := range c.Height() {
+			for x := range c.Width() {
+				p, alpha, err := c.PixelAlpha(x, y)
+				if err != nil {
+					return err
+				}
+
+				for i, v := range [5]float64{p.X, p.Y, p.Z, p.Luminance(), alpha} {
+					channels[i].add(v)
+				}
+			}
+		}
+
+		fmt.Fprintf(stdout, "%s: %dx%d, %d pixels\n", file, c.Width(), c.Height(), c.Size())
+		for i, name := range [5]string{"red", "green", "blue", "luminance", "alpha"} {
+			fmt.Fprintf(stdout, "  %-10s %v\n", name+":", channels[i])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// errUsage is returned by the commands for invalid arguments, after
+// printing their usage.
+var errUsage = errors.New("invalid arguments")
+
+// command is a raytracer subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) error
+}
+
+// commands are the subcommands in the order of the usage.
+var commands = []command{
+	{name: "render", summary: "render a scene to a PPM or PNG image (not supported yet, it fails after validating the scene)", run: runRender},
+	{name: "convert", summary: "convert an image between the PPM and PNG formats", run: runConvert},
+	{name: "diff", summary: "compare two images, exiting with 1 when they differ", run: runDiff},
+	{name: "info", summary: "print the size and the color statistics of images", run: runInfo},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the subcommand in args and returns the exit code: 0 on success,
+// 1 on failure (or when diff finds differences) and 2 for invalid arguments.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "-help" {
+		usage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdout, stderr)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		case errors.Is(err, errDifferent):
+			return 1
+		}
+
+		fmt.Fprintf(stderr, "raytracer %s: %v\n", cmd.name, err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "raytracer: unknown command %q\n\n", args[0])
+	usage(stderr)
+
+	return 2
+}
+
+// usage writes the list of subcommands to w.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: raytracer <command> [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+}
+
+// newFlagSet creates the flag set of cmd, writing its errors and usage,
+// with the arguments described by args, to stderr.
+func newFlagSet(cmd, args string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("raytracer "+cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: raytracer %s %s\n", cmd, args)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseArgs parses the flags in args, which may come before, between or
+// after the positional arguments, and checks that there are n positional
+// arguments, or at least one when n is negative.
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for {
+		// The flag set already printed the error and the usage.
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if (n < 0 && len(positional) == 0) || (n >= 0 && len(positional) != n) {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return positional, nil
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"strings"
+	"testing"
+)
+
+// writeImage writes a width x 1 canvas filled with color to the file name
+// in dir.
+func writeImage(t *testing.T, dir, name string, width int, color feature.Tuple) string {
+	t.Helper()
+
+	c, err := feature.NewCanvas(width, 1)
+	if err != nil {
+		t.Fatalf("error creating a canvas: %v", err)
+	}
+	c.Fill(color)
+
+	path := filepath.Join(dir, name)
+	if err := c.WriteFile(path); err != nil {
+		t.Fatalf("error writing %s: %v", path, err)
+	}
+
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	red := writeImage(t, dir, "red.ppm", 2, feature.ColorRed)
+	redPNG := writeImage(t, dir, "red.png", 2, feature.ColorRed)
+	blue := writeImage(t, dir, "blue.ppm", 2, feature.ColorBlue)
+	small := writeImage(t, dir, "small.ppm", 1, feature.ColorRed)
+	translucent := filepath.Join(dir, "translucent.png")
+	c, err := feature.NewCanvas(2, 1)
+	if err != nil {
+		t.Fatalf("error creating a canvas: %v", err)
+	}
+	c.Fill(feature.ColorRed)
+	if err := c.WritePixelAlpha(1, 0, feature.ColorRed, 0.5); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if err := c.WriteFile(translucent); err != nil {
+		t.Fatalf("error writing %s: %v", translucent, err)
+	}
+	converted := filepath.Join(dir, "converted.png")
+	heat := filepath.Join(dir, "heat.ppm")
+	scene := filepath.Join(dir, "scene.yaml")
+	if err := os.WriteFile(scene, []byte("- add: sphere\n"), 0o600); err != nil {
+		t.Fatalf("error writing the scene: %v", err)
+	}
+	invalidScene := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidScene, []byte("- add: teapot\n"), 0o600); err != nil {
+		t.Fatalf("error writing the scene: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{
+			name:   "no command",
+			args:   nil,
+			code:   2,
+			stderr: "usage: raytracer <command>",
+		},
+		{
+			name:   "unknown command",
+			args:   []string{"paint"},
+			code:   2,
+			stderr: `unknown command "paint"`,
+		},
+		{
+			name:   "render",
+			args:   []string{"render", scene, "-o", "out.png"},
+			code:   1,
+			stderr: "no renderer yet, the scene was validated but not rendered: " + scene + " has 1 shapes and 0 lights",
+		},
+		{
+			name:   "unknown flag",
+			args:   []string{"render", scene, "-size", "10"},
+			code:   2,
+			stderr: "flag provided but not defined: -size",
+		},
+		{
+			name:   "render invalid scene",
+			args:   []string{"render", invalidScene},
+			code:   1,
+			stderr: `line 1: unknown shape "teapot"`,
+		},
+		{
+			name: "convert",
+			args: []string{"convert", red, converted},
+			code: 0,
+		},
+		{
+			name:   "convert without output",
+			args:   []string{"convert", red},
+			code:   2,
+			stderr: "usage: raytracer convert in.ppm out.png",
+		},
+		{
+			name:   "diff equal",
+			args:   []string{"diff", red, redPNG},
+			code:   0,
+			stdout: "max error:  0\n",
+		},
+		{
+			name:   "diff different",
+			args:   []string{"diff", red, blue, "-o", heat},
+			code:   1,
+			stdout: "max error:  1\nmean error: 0.6666666666666666\n",
+		},
+		{
+			name:   "diff alpha",
+			args:   []string{"diff", redPNG, translucent},
+			code:   1,
+			stdout: "max error:  0\n",
+		},
+		{
+			name:   "diff within tolerance",
+			args:   []string{"diff", "-tolerance", "1", red, blue},
+			code:   0,
+			stdout: "max error:  1\n",
+		},
+		{
+			name:   "diff size mismatch",
+			args:   []string{"diff", red, small},
+			code:   1,
+			stderr: "canvas sizes don't match: 2x1 and 1x1",
+		},
+		{
+			name:   "info",
+			args:   []string{"info", red},
+			code:   0,
+			stdout: red + ": 2x1, 2 pixels\n  red:       min 1, mean 1, max 1\n  green:     min 0, mean 0, max 0\n",
+		},
+		{
+			name:   "info missing file",
+			args:   []string{"info", filepath.Join(dir, "missing.png")},
+			code:   1,
+			stderr: "no such file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+			code := run(test.args, &stdout, &stderr)
+
+			if code != test.code {
+				t.Errorf("%q: wants exit code %d and got %d (stderr %q)", test.name, test.code, code, stderr.String())
+			}
+			if !strings.HasPrefix(stdout.String(), test.stdout) {
+				t.Errorf("%q: wants stdout starting with %q and got %q", test.name, test.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("%q: wants stderr with %q and got %q", test.name, test.stderr, stderr.String())
+			}
+		})
+	}
+
+	got, err := feature.ReadFile(converted)
+	if err != nil {
+		t.Fatalf("error reading the converted image: %v", err)
+	}
+	if p, _ := got.Pixel(1, 0); !feature.ColorRed.IsEqual(p) {
+		t.Errorf("wants a red converted image and got %v", p)
+	}
+	if _, err := feature.ReadFile(heat); err != nil {
+		t.Errorf("error reading the heat map: %v", err)
+	}
+}
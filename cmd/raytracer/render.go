@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"ray-tracer/scene"
+)
+
+// errNoRenderer is returned by render after loading the scene: the tree has
+// no world, camera or shading code to render it with yet.
+var errNoRenderer = errors.New("no renderer yet, the scene was validated but not rendered")
+
+func runRender(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("render", "scene.yaml|scene.json -o out.png", stderr)
+	fs.String("o", "out.ppm", "PPM or PNG output file")
+
+	files, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	s, err := scene.Load(files[0])
+	if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s has %d shapes and %d lights", errNoRenderer, files[0], len(s.Shapes), len(s.Lights))
+}
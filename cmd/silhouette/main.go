@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"ray-tracer/feature"
+)
+
+const (
+	// wallZ is the distance of the wall from the origin, along the z axis.
+	wallZ = 10.0
+	// wallSize is the width and the height of the wall, enough to show the
+	// whole shadow of the unit sphere.
+	wallSize = 7.0
+)
+
+// rayOrigin is the light source, behind the sphere.
+var rayOrigin = feature.NewPoint(0, 0, -5)
+
+// intersect returns the smallest non-negative distance t where the ray with
+// origin and a normalized direction hits the unit sphere at the world origin.
+func intersect(origin, direction feature.Tuple) (float64, bool) {
+	sphereToRay := origin.SubUnchecked(feature.NewPoint(0, 0, 0))
+
+	b := 2 * direction.DotProductUnchecked(sphereToRay)
+	c := sphereToRay.DotProductUnchecked(sphereToRay) - 1
+	discriminant := b*b - 4*c
+	if discriminant < 0 {
+		return 0, false
+	}
+
+	root := math.Sqrt(discriminant)
+	for _, t := range [2]float64{(-b - root) / 2, (-b + root) / 2} {
+		if t >= 0 {
+			return t, true
+		}
+	}
+
+	return 0, false
+}
+
+// silhouette casts a ray from rayOrigin to each pixel of a square wall
+// behind the unit sphere, painting with color the pixels whose ray hits it.
+func silhouette(size int, color feature.Tuple) (*feature.Canvas, error) {
+	c, err := feature.NewCanvas(size, size)
+	if err != nil {
+		return nil, err
+	}
+
+	pixelSize := wallSize / float64(size)
+	half := wallSize / 2
+	for y := range size {
+		// The world y points up, the canvas y points down.
+		worldY := half - pixelSize*(float64(y)+0.5)
+		for x := range size {
+			worldX := -half + pixelSize*(float64(x)+0.5)
+
+			// The wall is never at the ray origin, so the direction isn't zero.
+			direction := feature.NewPoint(worldX, worldY, wallZ).SubUnchecked(rayOrigin).NormalizeUnchecked()
+			if _, ok := intersect(rayOrigin, direction); ok {
+				if err := c.WritePixel(x, y, color); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return c, nil
+}
+
+func main() {
+	size := flag.Int("size", 100, "canvas width and height, in pixels")
+	color := flag.String("color", "#ff0000", "silhouette color, as a hex code or a temperature like 3200K")
+	out := flag.String("o", "silhouette.ppm", "PPM or PNG output file")
+	flag.Parse()
+
+	col, err := feature.ParseColor(*color)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid color %q: %v\n", *color, err)
+		os.Exit(2)
+	}
+
+	c, err := silhouette(*size, col)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to cast the silhouette: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := c.WriteFile(*out); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+}
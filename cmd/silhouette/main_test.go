@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name      string
+		origin    feature.Tuple
+		direction feature.Tuple
+		want      float64
+		hit       bool
+	}{
+		{
+			name:      "through the center",
+			origin:    feature.NewPoint(0, 0, -5),
+			direction: feature.NewVector(0, 0, 1),
+			want:      4,
+			hit:       true,
+		},
+		{
+			name:      "tangent",
+			origin:    feature.NewPoint(0, 1, -5),
+			direction: feature.NewVector(0, 0, 1),
+			want:      5,
+			hit:       true,
+		},
+		{
+			name:      "misses",
+			origin:    feature.NewPoint(0, 2, -5),
+			direction: feature.NewVector(0, 0, 1),
+			hit:       false,
+		},
+		{
+			name:      "inside the sphere",
+			origin:    feature.NewPoint(0, 0, 0),
+			direction: feature.NewVector(0, 0, 1),
+			want:      1,
+			hit:       true,
+		},
+		{
+			name:      "behind the ray",
+			origin:    feature.NewPoint(0, 0, 5),
+			direction: feature.NewVector(0, 0, 1),
+			hit:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, hit := intersect(test.origin, test.direction)
+
+			if hit != test.hit {
+				t.Fatalf("%q: wants hit %t and got %t", test.name, test.hit, hit)
+			}
+			if hit && math.Abs(got-test.want) > 0.00001 {
+				t.Errorf("%q: wants t %f and got %f", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestSilhouette(t *testing.T) {
+	c, err := silhouette(20, feature.ColorRed)
+	if err != nil {
+		t.Fatalf("error casting the silhouette: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		x, y int
+		want feature.Tuple
+	}{
+		{name: "center", x: 10, y: 10, want: feature.ColorRed},
+		{name: "corner", x: 0, y: 0, want: feature.ColorBlack},
+		{name: "edge", x: 10, y: 0, want: feature.ColorBlack},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.Pixel(test.x, test.y)
+			if err != nil {
+				t.Fatalf("%q: error reading the pixel: %v", test.name, err)
+			}
+
+			if !test.want.IsEqual(got) {
+				t.Errorf("%q: wants %v and got %v", test.name, test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package feature
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNotPointBounds = errors.New("bounding box corners must be points")
+
+// BoundingBox is an axis-aligned box in space, defined by its Min and Max
+// corners. An empty box has Min at +Inf and Max at -Inf, so adding any point
+// to it makes a box with just that point.
+type BoundingBox struct {
+	Min Tuple
+	Max Tuple
+}
+
+// NewBoundingBox creates a new empty BoundingBox.
+func NewBoundingBox() BoundingBox {
+	inf := math.Inf(1)
+
+	return BoundingBox{
+		Min: NewPoint(inf, inf, inf),
+		Max: NewPoint(-inf, -inf, -inf),
+	}
+}
+
+// NewBoundingBoxFromPoints creates a new BoundingBox with all the points
+// inside it.
+// It returns an error if any Tuple isn't a point.
+func NewBoundingBoxFromPoints(points ...Tuple) (BoundingBox, error) {
+	b := NewBoundingBox()
+	for _, p := range points {
+		if !p.IsPoint() {
+			return b, ErrNotPointBounds
+		}
+
+		b = b.AddPoint(p)
+	}
+
+	return b, nil
+}
+
+// IsEmpty returns if the BoundingBox has no points inside it.
+func (b BoundingBox) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+// AddPoint returns a new BoundingBox grown to have the point p inside it.
+func (b BoundingBox) AddPoint(p Tuple) BoundingBox {
+	return BoundingBox{
+		Min: NewPoint(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z)),
+		Max: NewPoint(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z)),
+	}
+}
+
+// Union returns the smallest BoundingBox with both boxes inside it.
+func (b BoundingBox) Union(o BoundingBox) BoundingBox {
+	if o.IsEmpty() {
+		return b
+	}
+
+	return b.AddPoint(o.Min).AddPoint(o.Max)
+}
+
+// Intersection returns the BoundingBox shared by both boxes, which is empty
+// when they don't overlap.
+func (b BoundingBox) Intersection(o BoundingBox) BoundingBox {
+	r := BoundingBox{
+		Min: NewPoint(math.Max(b.Min.X, o.Min.X), math.Max(b.Min.Y, o.Min.Y), math.Max(b.Min.Z, o.Min.Z)),
+		Max: NewPoint(math.Min(b.Max.X, o.Max.X), math.Min(b.Max.Y, o.Max.Y), math.Min(b.Max.Z, o.Max.Z)),
+	}
+	if r.IsEmpty() {
+		return NewBoundingBox()
+	}
+
+	return r
+}
+
+// ContainsPoint returns if the point p is inside the BoundingBox, including
+// its faces.
+func (b BoundingBox) ContainsPoint(p Tuple) bool {
+	return b.Min.X <= p.X && p.X <= b.Max.X &&
+		b.Min.Y <= p.Y && p.Y <= b.Max.Y &&
+		b.Min.Z <= p.Z && p.Z <= b.Max.Z
+}
+
+// ContainsBox returns if the box o is entirely inside the BoundingBox.
+func (b BoundingBox) ContainsBox(o BoundingBox) bool {
+	if o.IsEmpty() {
+		return true
+	}
+
+	return b.ContainsPoint(o.Min) && b.ContainsPoint(o.Max)
+}
+
+// Center returns the point in the middle of the BoundingBox.
+func (b BoundingBox) Center() Tuple {
+	return NewPoint((b.Min.X+b.Max.X)/2, (b.Min.Y+b.Max.Y)/2, (b.Min.Z+b.Max.Z)/2)
+}
+
+// Transform returns the BoundingBox of the box corners transformed by m.
+// The result is axis-aligned again, so it may be larger than the
+// transformed box.
+func (b BoundingBox) Transform(m Matrix) BoundingBox {
+	if b.IsEmpty() {
+		return b
+	}
+
+	r := NewBoundingBox()
+	for _, x := range [2]float64{b.Min.X, b.Max.X} {
+		for _, y := range [2]float64{b.Min.Y, b.Max.Y} {
+			for _, z := range [2]float64{b.Min.Z, b.Max.Z} {
+				r = r.AddPoint(m.MulTuple(NewPoint(x, y, z)))
+			}
+		}
+	}
+
+	return r
+}
+
+// Split divides the BoundingBox in two halves across its longest axis. An
+// empty BoundingBox splits into two empty boxes.
+func (b BoundingBox) Split() (BoundingBox, BoundingBox) {
+	if b.IsEmpty() {
+		return NewBoundingBox(), NewBoundingBox()
+	}
+
+	dx, dy, dz := b.Max.X-b.Min.X, b.Max.Y-b.Min.Y, b.Max.Z-b.Min.Z
+	left, right := b, b
+
+	switch {
+	case dx >= dy && dx >= dz:
+		mid := b.Min.X + dx/2
+		left.Max.X, right.Min.X = mid, mid
+	case dy >= dz:
+		mid := b.Min.Y + dy/2
+		left.Max.Y, right.Min.Y = mid, mid
+	default:
+		mid := b.Min.Z + dz/2
+		left.Max.Z, right.Min.Z = mid, mid
+	}
+
+	return left, right
+}
+
+// IntersectsRay returns if the ray from origin with direction hits the
+// BoundingBox, using the slab test. It also returns the distances, in
+// multiples of direction, where the ray enters and leaves the box; the
+// enter distance is negative when the origin is inside the box.
+func (b BoundingBox) IntersectsRay(origin, direction Tuple) (bool, float64, float64) {
+	if b.IsEmpty() {
+		return false, 0, 0
+	}
+
+	xmin, xmax := slab(origin.X, direction.X, b.Min.X, b.Max.X)
+	ymin, ymax := slab(origin.Y, direction.Y, b.Min.Y, b.Max.Y)
+	zmin, zmax := slab(origin.Z, direction.Z, b.Min.Z, b.Max.Z)
+
+	tmin := math.Max(xmin, math.Max(ymin, zmin))
+	tmax := math.Min(xmax, math.Min(ymax, zmax))
+
+	if tmin > tmax || tmax < 0 {
+		return false, 0, 0
+	}
+
+	return true, tmin, tmax
+}
+
+// slab returns the distances where a ray crosses the two planes at min and
+// max of one axis. A ray parallel to the planes is either always inside the
+// slab or never, so it gets infinite distances.
+func slab(origin, direction, min, max float64) (float64, float64) {
+	// Checking for zero also covers -0, which would flip the infinities
+	// given by dividing by it.
+	if direction == 0 {
+		if origin < min || origin > max {
+			return math.Inf(1), math.Inf(-1)
+		}
+		return math.Inf(-1), math.Inf(1)
+	}
+
+	tmin := (min - origin) / direction
+	tmax := (max - origin) / direction
+
+	if tmin > tmax {
+		return tmax, tmin
+	}
+
+	return tmin, tmax
+}
@@ -0,0 +1,226 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func newBox(t *testing.T, min, max feature.Tuple) feature.BoundingBox {
+	t.Helper()
+
+	b, err := feature.NewBoundingBoxFromPoints(min, max)
+	if err != nil {
+		t.Fatalf("error creating a bounding box: %v", err)
+	}
+
+	return b
+}
+
+func TestNewBoundingBox(t *testing.T) {
+	if b := feature.NewBoundingBox(); !b.IsEmpty() {
+		t.Errorf("expected an empty box, got %+v", b)
+	}
+
+	b, err := feature.NewBoundingBoxFromPoints(
+		feature.NewPoint(-5, 2, 0),
+		feature.NewPoint(7, 0, -3),
+	)
+	if err != nil {
+		t.Fatalf("error creating a bounding box: %v", err)
+	}
+	if !b.Min.IsEqual(feature.NewPoint(-5, 0, -3)) || !b.Max.IsEqual(feature.NewPoint(7, 2, 0)) {
+		t.Errorf("wants min %v and max %v and got %+v", feature.NewPoint(-5, 0, -3), feature.NewPoint(7, 2, 0), b)
+	}
+
+	if _, err := feature.NewBoundingBoxFromPoints(feature.NewVector(1, 1, 1)); !errors.Is(err, feature.ErrNotPointBounds) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrNotPointBounds)
+	}
+}
+
+func TestBoundingBoxUnionAndIntersection(t *testing.T) {
+	a := newBox(t, feature.NewPoint(-5, -2, 0), feature.NewPoint(7, 4, 4))
+	b := newBox(t, feature.NewPoint(8, -7, -2), feature.NewPoint(14, 2, 8))
+
+	union := a.Union(b)
+	if !union.Min.IsEqual(feature.NewPoint(-5, -7, -2)) || !union.Max.IsEqual(feature.NewPoint(14, 4, 8)) {
+		t.Errorf("union got %+v", union)
+	}
+	if got := a.Union(feature.NewBoundingBox()); got != a {
+		t.Errorf("union with an empty box wants %+v and got %+v", a, got)
+	}
+
+	if got := a.Intersection(b); !got.IsEmpty() {
+		t.Errorf("expected disjoint boxes to have an empty intersection, got %+v", got)
+	}
+
+	c := newBox(t, feature.NewPoint(0, 0, 0), feature.NewPoint(10, 10, 10))
+	got := a.Intersection(c)
+	if !got.Min.IsEqual(feature.NewPoint(0, 0, 0)) || !got.Max.IsEqual(feature.NewPoint(7, 4, 4)) {
+		t.Errorf("intersection got %+v", got)
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	box := newBox(t, feature.NewPoint(5, -2, 0), feature.NewPoint(11, 4, 7))
+
+	points := []struct {
+		point feature.Tuple
+		want  bool
+	}{
+		{point: feature.NewPoint(5, -2, 0), want: true},
+		{point: feature.NewPoint(11, 4, 7), want: true},
+		{point: feature.NewPoint(8, 1, 3), want: true},
+		{point: feature.NewPoint(3, 0, 3), want: false},
+		{point: feature.NewPoint(8, -4, 3), want: false},
+		{point: feature.NewPoint(8, 1, -1), want: false},
+		{point: feature.NewPoint(13, 1, 3), want: false},
+		{point: feature.NewPoint(8, 5, 3), want: false},
+		{point: feature.NewPoint(8, 1, 8), want: false},
+	}
+	for _, test := range points {
+		if got := box.ContainsPoint(test.point); got != test.want {
+			t.Errorf("ContainsPoint(%v) wants %v and got %v", test.point, test.want, got)
+		}
+	}
+
+	boxes := []struct {
+		min  feature.Tuple
+		max  feature.Tuple
+		want bool
+	}{
+		{min: feature.NewPoint(5, -2, 0), max: feature.NewPoint(11, 4, 7), want: true},
+		{min: feature.NewPoint(6, -1, 1), max: feature.NewPoint(10, 3, 6), want: true},
+		{min: feature.NewPoint(4, -3, -1), max: feature.NewPoint(10, 3, 6), want: false},
+		{min: feature.NewPoint(6, -1, 1), max: feature.NewPoint(12, 5, 8), want: false},
+	}
+	for _, test := range boxes {
+		if got := box.ContainsBox(newBox(t, test.min, test.max)); got != test.want {
+			t.Errorf("ContainsBox(%v, %v) wants %v and got %v", test.min, test.max, test.want, got)
+		}
+	}
+}
+
+func TestBoundingBoxTransform(t *testing.T) {
+	box := newBox(t, feature.NewPoint(-1, -1, -1), feature.NewPoint(1, 1, 1))
+
+	x, _ := feature.NewQuaternionFromAxisAngle(feature.NewVector(1, 0, 0), math.Pi/4)
+	y, _ := feature.NewQuaternionFromAxisAngle(feature.NewVector(0, 1, 0), math.Pi/4)
+	m := x.Mul(y).Matrix()
+
+	got := box.Transform(m)
+	wantMin := feature.NewPoint(-1.41421, -1.70710, -1.70710)
+	wantMax := feature.NewPoint(1.41421, 1.70710, 1.70710)
+	if !got.Min.IsEqual(wantMin) || !got.Max.IsEqual(wantMax) {
+		t.Errorf("wants min %v and max %v and got %+v", wantMin, wantMax, got)
+	}
+
+	translate := feature.IdentityMatrix
+	translate[0][3] = 10
+	got = box.Transform(translate)
+	if !got.Min.IsEqual(feature.NewPoint(9, -1, -1)) || !got.Max.IsEqual(feature.NewPoint(11, 1, 1)) {
+		t.Errorf("translation got %+v", got)
+	}
+}
+
+func TestBoundingBoxSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		min      feature.Tuple
+		max      feature.Tuple
+		leftMax  feature.Tuple
+		rightMin feature.Tuple
+	}{
+		{
+			name:     "cube",
+			min:      feature.NewPoint(-1, -4, -5),
+			max:      feature.NewPoint(9, 6, 5),
+			leftMax:  feature.NewPoint(4, 6, 5),
+			rightMin: feature.NewPoint(4, -4, -5),
+		},
+		{
+			name:     "y is longest",
+			min:      feature.NewPoint(-1, -2, -3),
+			max:      feature.NewPoint(5, 8, 3),
+			leftMax:  feature.NewPoint(5, 3, 3),
+			rightMin: feature.NewPoint(-1, 3, -3),
+		},
+		{
+			name:     "z is longest",
+			min:      feature.NewPoint(-1, -2, -3),
+			max:      feature.NewPoint(5, 3, 7),
+			leftMax:  feature.NewPoint(5, 3, 2),
+			rightMin: feature.NewPoint(-1, -2, 2),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			left, right := newBox(t, test.min, test.max).Split()
+
+			if !left.Min.IsEqual(test.min) || !left.Max.IsEqual(test.leftMax) {
+				t.Errorf("%s: left wants %v to %v and got %+v", test.name, test.min, test.leftMax, left)
+			}
+			if !right.Min.IsEqual(test.rightMin) || !right.Max.IsEqual(test.max) {
+				t.Errorf("%s: right wants %v to %v and got %+v", test.name, test.rightMin, test.max, right)
+			}
+		})
+	}
+
+	left, right := feature.NewBoundingBox().Split()
+	if !left.IsEmpty() || !right.IsEmpty() {
+		t.Errorf("expected an empty box to split into empty boxes but got %+v and %+v", left, right)
+	}
+}
+
+func TestBoundingBoxIntersectsRay(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	box := newBox(t, feature.NewPoint(5, -2, 0), feature.NewPoint(11, 4, 7))
+	empty := feature.NewBoundingBox()
+	disjoint := box.Intersection(newBox(t, feature.NewPoint(20, 20, 20), feature.NewPoint(21, 21, 21)))
+
+	tests := []struct {
+		name      string
+		box       *feature.BoundingBox
+		origin    feature.Tuple
+		direction feature.Tuple
+		want      bool
+		tmin      float64
+		tmax      float64
+	}{
+		{name: "+x", origin: feature.NewPoint(15, 1, 2), direction: feature.NewVector(-1, 0, 0), want: true, tmin: 4, tmax: 10},
+		{name: "-x", origin: feature.NewPoint(-5, -1, 4), direction: feature.NewVector(1, 0, 0), want: true, tmin: 10, tmax: 16},
+		{name: "+y", origin: feature.NewPoint(7, 6, 5), direction: feature.NewVector(0, -1, 0), want: true, tmin: 2, tmax: 8},
+		{name: "-z", origin: feature.NewPoint(9, -1, -8), direction: feature.NewVector(0, 0, 1), want: true, tmin: 8, tmax: 15},
+		{name: "inside", origin: feature.NewPoint(8, 1, 3), direction: feature.NewVector(0, 0, 2), want: true, tmin: -1.5, tmax: 2},
+		{name: "on a face", origin: feature.NewPoint(5, 1, 3), direction: feature.NewVector(0, 1, 0), want: true, tmin: -3, tmax: 3},
+		{name: "miss 1", origin: feature.NewPoint(9, -1, -8), direction: feature.NewVector(2, 4, 6), want: false},
+		{name: "miss 2", origin: feature.NewPoint(8, 6, -1), direction: feature.NewVector(2, 4, 6), want: false},
+		{name: "miss 3", origin: feature.NewPoint(12, 5, 4), direction: feature.NewVector(6, 4, 2), want: false},
+		{name: "behind", origin: feature.NewPoint(15, 1, 2), direction: feature.NewVector(1, 0, 0), want: false},
+		{name: "negative zero", origin: feature.NewPoint(9, 1, -1), direction: feature.NewVector(negZero, negZero, 1), want: true, tmin: 1, tmax: 8},
+		{name: "negative zero on a face", origin: feature.NewPoint(5, 4, -1), direction: feature.NewVector(negZero, negZero, 1), want: true, tmin: 1, tmax: 8},
+		{name: "negative zero miss", origin: feature.NewPoint(4, 1, -1), direction: feature.NewVector(negZero, 0, 1), want: false},
+		{name: "empty", box: &empty, origin: feature.NewPoint(0, 0, 0), direction: feature.NewVector(1, 2, 3), want: false},
+		{name: "disjoint intersection", box: &disjoint, origin: feature.NewPoint(8, 1, -1), direction: feature.NewVector(0.1, 0.1, 1), want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := box
+			if test.box != nil {
+				b = *test.box
+			}
+
+			got, tmin, tmax := b.IntersectsRay(test.origin, test.direction)
+
+			if got != test.want {
+				t.Fatalf("%s: wants %v and got %v", test.name, test.want, got)
+			}
+			if got && (math.Abs(tmin-test.tmin) > 0.00001 || math.Abs(tmax-test.tmax) > 0.00001) {
+				t.Errorf("%s: wants %f to %f and got %f to %f", test.name, test.tmin, test.tmax, tmin, tmax)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package feature
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -14,46 +15,79 @@ const (
 var (
 	ErrInvalidCanvasSize  = errors.New("invalid canvas size")
 	ErrInvalidCanvasPoint = errors.New("invalid canvas point")
+	ErrInvalidCanvasArea  = errors.New("invalid canvas area")
 )
 
 // Canvas is a rectangular (widht x height) grid of pixels, where each
-// pixel has a color setting.
+// pixel has a color setting and an alpha (coverage) between 0.0 for
+// transparent and 1.0 for opaque.
 type Canvas struct {
 	width  int
 	height int
 	pixels []Tuple
+	alpha  []float64
 }
 
 // NewCanvas creates a new Canvas with width and height sizes, where each
-// pixel is initialized to black (0, 0, 0)
+// pixel is initialized to opaque black (0, 0, 0)
+// It returns an error if any size isn't positive or their product overflows.
 func NewCanvas(width, height int) (*Canvas, error) {
-	if width <= 0 || height <= 0 {
+	if !validCanvasSize(width, height) {
 		return nil, ErrInvalidCanvasSize
 	}
 
 	pixels := make([]Tuple, width*height)
+	alpha := make([]float64, width*height)
 	for i := range width * height {
 		pixels[i] = ColorBlack
+		alpha[i] = 1.0
 	}
 
 	c := Canvas{
 		width:  width,
 		height: height,
 		pixels: pixels,
+		alpha:  alpha,
 	}
 
 	return &c, nil
 }
 
+// validCanvasSize returns if a canvas with width and height sizes can be
+// created: both are positive and 4*width*height, the number of channels of
+// a Canvas32, doesn't overflow.
+func validCanvasSize(width, height int) bool {
+	return width > 0 && height > 0 && width <= math.MaxInt/4/height
+}
+
 // Size returns the Canvas size.
 func (c *Canvas) Size() int {
 	return c.width * c.height
 }
 
-// Size returns the Canvas size.
+// Width returns the Canvas width.
+func (c *Canvas) Width() int {
+	return c.width
+}
+
+// Height returns the Canvas height.
+func (c *Canvas) Height() int {
+	return c.height
+}
+
+// Fill changes the color of every pixel of the Canvas, making them opaque.
 func (c *Canvas) Fill(color Tuple) {
 	for i := range c.pixels {
 		c.pixels[i] = color
+		c.alpha[i] = 1.0
+	}
+}
+
+// Clear makes every pixel of the Canvas transparent black.
+func (c *Canvas) Clear() {
+	for i := range c.pixels {
+		c.pixels[i] = ColorBlack
+		c.alpha[i] = 0.0
 	}
 }
 
@@ -72,33 +106,65 @@ func (c *Canvas) Pixel(x, y int) (Tuple, error) {
 	return p, nil
 }
 
-// WritePixel cheanges the color of a point in the position x and y.
+// PixelAlpha returns the pixel color and alpha in the position x and y.
+// It returns an error if any of the positions be invalid.
+func (c *Canvas) PixelAlpha(x, y int) (Tuple, float64, error) {
+	pos, err := c.xy2pos(x, y)
+	if err != nil {
+		return Tuple{}, 0, err
+	}
+
+	return c.pixels[pos], c.alpha[pos], nil
+}
+
+// WritePixel cheanges the color of a point in the position x and y, making
+// it opaque.
 func (c *Canvas) WritePixel(x, y int, color Tuple) error {
+	return c.WritePixelAlpha(x, y, color, 1.0)
+}
+
+// WritePixelAlpha changes the color and alpha of a point in the position x
+// and y. A ray that misses everything can be written with alpha 0.0.
+// It returns an error if alpha is out of [0, 1].
+func (c *Canvas) WritePixelAlpha(x, y int, color Tuple, alpha float64) error {
+	if !(alpha >= 0 && alpha <= 1) {
+		return ErrInvalidAlpha
+	}
+
 	pos, err := c.xy2pos(x, y)
 	if err != nil {
 		return err
 	}
 
 	c.pixels[pos] = color
+	c.alpha[pos] = alpha
 
 	return nil
 }
 
 // ToPPM returns an PPM version of the canvas
 func (c *Canvas) ToPPM(identifier string, maxColor int) string {
+	return toPPM(identifier, maxColor, c.width, c.height, func(i int) Tuple {
+		return c.pixels[i]
+	})
+}
+
+// toPPM returns an PPM version of an image with width and height sizes,
+// where pixel returns the color of each pixel in row-major order.
+func toPPM(identifier string, maxColor, width, height int, pixel func(i int) Tuple) string {
 	const maxSpace = 70
 
 	ppm := strings.Builder{}
 
 	// Header
 	ppm.WriteString(fmt.Sprintf("%s\n", identifier))
-	ppm.WriteString(fmt.Sprintf("%d %d\n", c.width, c.height))
+	ppm.WriteString(fmt.Sprintf("%d %d\n", width, height))
 	ppm.WriteString(fmt.Sprintf("%d\n", maxColor))
 
 	// Data
 	line := ""
-	for _, pixel := range c.pixels {
-		r, g, b := pixel.ColorString(maxColor)
+	for i := range width * height {
+		r, g, b := pixel(i).ColorString(maxColor)
 
 		if line == "" {
 			line = r
@@ -142,7 +208,7 @@ func (c *Canvas) xy2pos(x, y int) (int, error) {
 		return 0, ErrInvalidCanvasPoint
 	}
 
-	pos := x*c.width + y
+	pos := y*c.width + x
 
 	return pos, nil
 }
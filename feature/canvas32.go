@@ -0,0 +1,187 @@
+package feature
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Canvas32 is a Canvas that stores each pixel color and alpha as float32
+// values, using less than half the memory of a Canvas. It's meant for large
+// framebuffers where memory matters more than precision.
+type Canvas32 struct {
+	width  int
+	height int
+	// pixels holds the red, green, blue and alpha of each pixel, in
+	// row-major order.
+	pixels []float32
+}
+
+// NewCanvas32 creates a new Canvas32 with width and height sizes, where each
+// pixel is initialized to opaque black (0, 0, 0)
+// It returns an error on the same sizes as NewCanvas.
+func NewCanvas32(width, height int) (*Canvas32, error) {
+	if !validCanvasSize(width, height) {
+		return nil, ErrInvalidCanvasSize
+	}
+
+	pixels := make([]float32, 4*width*height)
+	for i := 3; i < len(pixels); i += 4 {
+		pixels[i] = 1.0
+	}
+
+	c := Canvas32{
+		width:  width,
+		height: height,
+		pixels: pixels,
+	}
+
+	return &c, nil
+}
+
+// Size returns the Canvas32 size.
+func (c *Canvas32) Size() int {
+	return c.width * c.height
+}
+
+// Width returns the Canvas32 width.
+func (c *Canvas32) Width() int {
+	return c.width
+}
+
+// Height returns the Canvas32 height.
+func (c *Canvas32) Height() int {
+	return c.height
+}
+
+// Fill changes the color of every pixel of the Canvas32, making them opaque.
+func (c *Canvas32) Fill(color Tuple) {
+	for i := 0; i < len(c.pixels); i += 4 {
+		c.set(i, color, 1.0)
+	}
+}
+
+// Pixel returns the pixel color in the position x and y.
+// It returns an error if any of the positions be invalid.
+func (c *Canvas32) Pixel(x, y int) (Tuple, error) {
+	p, _, err := c.PixelAlpha(x, y)
+
+	return p, err
+}
+
+// PixelAlpha returns the pixel color and alpha in the position x and y.
+// It returns an error if any of the positions be invalid.
+func (c *Canvas32) PixelAlpha(x, y int) (Tuple, float64, error) {
+	pos, err := c.xy2pos(x, y)
+	if err != nil {
+		return Tuple{}, 0, err
+	}
+
+	p := c.pixels[pos : pos+4]
+
+	return NewColor(float64(p[0]), float64(p[1]), float64(p[2])), float64(p[3]), nil
+}
+
+// WritePixel changes the color of a point in the position x and y, making
+// it opaque.
+func (c *Canvas32) WritePixel(x, y int, color Tuple) error {
+	return c.WritePixelAlpha(x, y, color, 1.0)
+}
+
+// WritePixelAlpha changes the color and alpha of a point in the position x
+// and y.
+// It returns an error if alpha is out of [0, 1].
+func (c *Canvas32) WritePixelAlpha(x, y int, color Tuple, alpha float64) error {
+	if !(alpha >= 0 && alpha <= 1) {
+		return ErrInvalidAlpha
+	}
+
+	pos, err := c.xy2pos(x, y)
+	if err != nil {
+		return err
+	}
+
+	c.set(pos, color, alpha)
+
+	return nil
+}
+
+// Canvas32 returns a copy of the Canvas with float32 storage.
+func (c *Canvas) Canvas32() *Canvas32 {
+	r, _ := NewCanvas32(c.width, c.height)
+	for i, p := range c.pixels {
+		r.set(4*i, p, c.alpha[i])
+	}
+
+	return r
+}
+
+// Canvas returns a copy of the Canvas32 with float64 storage, to use the
+// Canvas operations.
+func (c *Canvas32) Canvas() *Canvas {
+	r := blankCanvas(c.width, c.height)
+	for i := range r.pixels {
+		p := c.pixels[4*i : 4*i+4]
+		r.pixels[i] = NewColor(float64(p[0]), float64(p[1]), float64(p[2]))
+		r.alpha[i] = float64(p[3])
+	}
+
+	return r
+}
+
+// ToPPM returns an PPM version of the canvas.
+func (c *Canvas32) ToPPM(identifier string, maxColor int) string {
+	return toPPM(identifier, maxColor, c.width, c.height, func(i int) Tuple {
+		p := c.pixels[4*i : 4*i+4]
+		return NewColor(float64(p[0]), float64(p[1]), float64(p[2]))
+	})
+}
+
+// ToImage returns an 8 bits per channel RGBA version of the canvas, with
+// each color clamped to [0, 255].
+func (c *Canvas32) ToImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, c.width, c.height))
+	for y := range c.height {
+		for x := range c.width {
+			pos := 4 * (y*c.width + x)
+			p := c.pixels[pos : pos+4]
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(clamp(float64(p[0]), MaxColor)),
+				G: uint8(clamp(float64(p[1]), MaxColor)),
+				B: uint8(clamp(float64(p[2]), MaxColor)),
+				A: uint8(math.Round(float64(p[3]) * MaxColor)),
+			})
+		}
+	}
+
+	return img
+}
+
+// ToPNG writes an RGBA PNG version of the canvas to w.
+func (c *Canvas32) ToPNG(w io.Writer) error {
+	return png.Encode(w, c.ToImage())
+}
+
+// WriteFile writes the canvas to the file at path, as a PPM or a PNG image
+// following the path extension.
+func (c *Canvas32) WriteFile(path string) error {
+	return writeImageFile(path, c.ToPPM, c.ToPNG)
+}
+
+func (c *Canvas32) set(pos int, color Tuple, alpha float64) {
+	p := c.pixels[pos : pos+4]
+	p[0] = float32(color.X)
+	p[1] = float32(color.Y)
+	p[2] = float32(color.Z)
+	p[3] = float32(alpha)
+}
+
+func (c *Canvas32) xy2pos(x, y int) (int, error) {
+	if x < 0 || x >= c.width || y < 0 || y >= c.height {
+		return 0, ErrInvalidCanvasPoint
+	}
+
+	return 4 * (y*c.width + x), nil
+}
@@ -0,0 +1,166 @@
+package feature_test
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"reflect"
+	"testing"
+)
+
+func TestNewCanvas32(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		err    error
+	}{
+		{
+			name:   "valid",
+			width:  2,
+			height: 3,
+			err:    nil,
+		},
+		{
+			name:   "invalid",
+			width:  0,
+			height: 1,
+			err:    feature.ErrInvalidCanvasSize,
+		},
+		{
+			name:   "overflow",
+			width:  1 << 32,
+			height: 1 << 32,
+			err:    feature.ErrInvalidCanvasSize,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := feature.NewCanvas32(test.width, test.height)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Size() != test.width*test.height || got.Width() != test.width || got.Height() != test.height {
+				t.Errorf("%q: got a canvas %dx%d, expected %dx%d", test.name, got.Width(), got.Height(), test.width, test.height)
+			}
+
+			pixel, alpha, err := got.PixelAlpha(test.width-1, test.height-1)
+			if err != nil || !pixel.IsEqual(feature.ColorBlack) || alpha != 1 {
+				t.Errorf("%q: expected opaque black but got %+v with alpha %f and error %v", test.name, pixel, alpha, err)
+			}
+			if _, err := got.Pixel(test.width, 0); !errors.Is(err, feature.ErrInvalidCanvasPoint) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, feature.ErrInvalidCanvasPoint)
+			}
+		})
+	}
+}
+
+func TestCanvas32WritePixel(t *testing.T) {
+	canvas, err := feature.NewCanvas32(3, 2)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	if err := canvas.WritePixel(2, 1, feature.NewColor(0.1, 0.2, 0.3)); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if err := canvas.WritePixelAlpha(0, 1, feature.ColorRed, 0.25); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	for _, alpha := range []float64{2, math.NaN()} {
+		if err := canvas.WritePixelAlpha(0, 0, feature.ColorRed, alpha); !errors.Is(err, feature.ErrInvalidAlpha) {
+			t.Errorf("alpha %v: got error %v, expected error %v", alpha, err, feature.ErrInvalidAlpha)
+		}
+	}
+
+	got, err := canvas.Pixel(2, 1)
+	if err != nil {
+		t.Fatalf("error reading pixel: %v", err)
+	}
+	if want := feature.NewColor(0.1, 0.2, 0.3); !want.IsEqual(got) {
+		t.Errorf("wants %+v and got %+v", want, got)
+	}
+
+	// The conversions keep every pixel.
+	full := canvas.Canvas()
+	p, alpha, err := full.PixelAlpha(0, 1)
+	if err != nil || !p.IsEqual(feature.ColorRed) || alpha != 0.25 {
+		t.Errorf("expected red with alpha 0.25 but got %+v with alpha %f and error %v", p, alpha, err)
+	}
+
+	cmp, err := feature.Compare(full, full.Canvas32().Canvas())
+	if err != nil {
+		t.Fatalf("error comparing: %v", err)
+	}
+	if cmp.MaxError > 0.00001 {
+		t.Errorf("expected a lossless round trip, got max error %f", cmp.MaxError)
+	}
+
+	canvas.Fill(feature.ColorBlue)
+	if p, _ := canvas.Pixel(1, 0); !p.IsEqual(feature.ColorBlue) {
+		t.Errorf("wants %+v and got %+v", feature.ColorBlue, p)
+	}
+}
+
+func TestCanvas32Output(t *testing.T) {
+	canvas, err := feature.NewCanvas32(3, 2)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+	canvas.Fill(feature.NewColor(0.2, 0.4, 0.6))
+	if err := canvas.WritePixel(0, 0, feature.NewColor(1.5, 0.5, -1.5)); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if err := canvas.WritePixelAlpha(2, 1, feature.ColorBlue, 0.25); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	// The outputs match the ones of the same Canvas.
+	full := canvas.Canvas()
+	if got, want := canvas.ToPPM(feature.IdentifierP3, feature.MaxColor), full.ToPPM(feature.IdentifierP3, feature.MaxColor); got != want {
+		t.Errorf("wants PPM %q and got %q", want, got)
+	}
+	if got, want := canvas.ToImage(), full.ToImage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("wants image %+v and got %+v", want, got)
+	}
+
+	buf := bytes.Buffer{}
+	if err := canvas.ToPNG(&buf); err != nil {
+		t.Fatalf("error encoding PNG: %v", err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Fatalf("error decoding PNG: %v", err)
+	}
+
+	dir := t.TempDir()
+	for _, file := range []string{"out.ppm", "out.png"} {
+		path := filepath.Join(dir, file)
+		if err := canvas.WriteFile(path); err != nil {
+			t.Fatalf("%s: error writing the file: %v", file, err)
+		}
+
+		got, err := feature.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%s: error reading the file: %v", file, err)
+		}
+		if got.Width() != 3 || got.Height() != 2 {
+			t.Errorf("%s: got a canvas %dx%d, expected 3x2", file, got.Width(), got.Height())
+		}
+	}
+	if err := canvas.WriteFile(filepath.Join(dir, "out.jpg")); !errors.Is(err, feature.ErrUnknownImageFormat) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrUnknownImageFormat)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out.jpg")); !os.IsNotExist(err) {
+		t.Errorf("expected no file for an unknown format, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package feature
+
+import (
+	"errors"
+	"math"
+)
+
+// EdgeMode defines which pixels are read when a filter reaches beyond the
+// Canvas bounds.
+type EdgeMode int
+
+const (
+	// EdgeClamp repeats the nearest border pixel.
+	EdgeClamp EdgeMode = iota
+	// EdgeWrap reads the pixels from the opposite border.
+	EdgeWrap
+	// EdgeMirror reflects the pixels around the border.
+	EdgeMirror
+	// EdgeZero reads black pixels.
+	EdgeZero
+)
+
+var (
+	ErrInvalidKernel    = errors.New("invalid kernel")
+	ErrInvalidEdgeMode  = errors.New("invalid edge mode")
+	ErrInvalidSigma     = errors.New("invalid sigma")
+	ErrInvalidThreshold = errors.New("invalid threshold")
+)
+
+// Kernel is a rectangular (width x height) grid of weights used to convolve
+// a Canvas. Both sizes are odd, so the kernel has a center pixel.
+type Kernel struct {
+	width   int
+	height  int
+	weights []float64
+}
+
+// NewKernel creates a new Kernel with width and height sizes, where the
+// weights are in row-major order.
+// It returns an error if any size is even or doesn't match the weights.
+func NewKernel(width, height int, weights []float64) (Kernel, error) {
+	var k Kernel
+
+	if width <= 0 || height <= 0 || width%2 == 0 || height%2 == 0 || len(weights) != width*height {
+		return k, ErrInvalidKernel
+	}
+
+	k.width = width
+	k.height = height
+	k.weights = append([]float64(nil), weights...)
+
+	return k, nil
+}
+
+// GaussianKernel creates a one row Kernel with the gaussian weights for
+// sigma, covering three standard deviations on each side.
+func GaussianKernel(sigma float64) (Kernel, error) {
+	if sigma <= 0 {
+		return Kernel{}, ErrInvalidSigma
+	}
+
+	radius := int(math.Ceil(3 * sigma))
+	weights := make([]float64, 2*radius+1)
+	sum := 0.0
+	for i := range weights {
+		d := float64(i - radius)
+		weights[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+
+	return NewKernel(len(weights), 1, weights)
+}
+
+// Transpose returns the Kernel with rows and columns swapped.
+func (k Kernel) Transpose() Kernel {
+	t := Kernel{
+		width:   k.height,
+		height:  k.width,
+		weights: make([]float64, len(k.weights)),
+	}
+	for y := range k.height {
+		for x := range k.width {
+			t.weights[x*t.width+y] = k.weights[y*k.width+x]
+		}
+	}
+
+	return t
+}
+
+// Convolve returns a new Canvas where each pixel color is the weighted sum of
+// its neighbours, using the kernel weights and the edge mode for the pixels
+// beyond the Canvas bounds. The alpha of each pixel is kept.
+func (c *Canvas) Convolve(k Kernel, mode EdgeMode) (*Canvas, error) {
+	if k.width == 0 {
+		return nil, ErrInvalidKernel
+	}
+	if mode < EdgeClamp || mode > EdgeZero {
+		return nil, ErrInvalidEdgeMode
+	}
+
+	r := blankCanvas(c.width, c.height)
+	copy(r.alpha, c.alpha)
+	rx, ry := k.width/2, k.height/2
+	for y := range c.height {
+		for x := range c.width {
+			p := ColorBlack
+			for ky := range k.height {
+				sy, ok := edge(y+ky-ry, c.height, mode)
+				if !ok {
+					continue
+				}
+
+				for kx := range k.width {
+					sx, ok := edge(x+kx-rx, c.width, mode)
+					if !ok {
+						continue
+					}
+
+					weight := k.weights[ky*k.width+kx]
+					s := c.pixels[sy*c.width+sx]
+					p.X += s.X * weight
+					p.Y += s.Y * weight
+					p.Z += s.Z * weight
+				}
+			}
+
+			r.pixels[y*c.width+x] = p
+		}
+	}
+
+	return r, nil
+}
+
+// GaussianBlur returns a new Canvas blurred by a gaussian with sigma.
+func (c *Canvas) GaussianBlur(sigma float64, mode EdgeMode) (*Canvas, error) {
+	k, err := GaussianKernel(sigma)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.Convolve(k, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Convolve(k.Transpose(), mode)
+}
+
+// Sharpen returns a new Canvas with the edges enhanced by amount, where 0
+// keeps the Canvas as it is.
+func (c *Canvas) Sharpen(amount float64, mode EdgeMode) (*Canvas, error) {
+	k, err := NewKernel(3, 3, []float64{
+		0, -amount, 0,
+		-amount, 1 + 4*amount, -amount,
+		0, -amount, 0,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Convolve(k, mode)
+}
+
+// Bloom returns a new Canvas where the light above threshold luminance
+// bleeds into its neighbours. The bright part of each pixel is blurred with
+// sigma and added back to the Canvas scaled by intensity, so HDR values above
+// 1.0 glow instead of just being clamped.
+// It returns an error if threshold is negative.
+func (c *Canvas) Bloom(threshold, sigma, intensity float64) (*Canvas, error) {
+	if !(threshold >= 0) {
+		return nil, ErrInvalidThreshold
+	}
+
+	bright := blankCanvas(c.width, c.height)
+	for i, p := range c.pixels {
+		l := p.Luminance()
+		if l <= threshold {
+			continue
+		}
+
+		bright.pixels[i] = p.Mul((l - threshold) / l)
+	}
+
+	glow, err := bright.GaussianBlur(sigma, EdgeZero)
+	if err != nil {
+		return nil, err
+	}
+
+	r := blankCanvas(c.width, c.height)
+	copy(r.alpha, c.alpha)
+	for i, p := range c.pixels {
+		g := glow.pixels[i]
+		r.pixels[i] = NewColor(p.X+g.X*intensity, p.Y+g.Y*intensity, p.Z+g.Z*intensity)
+	}
+
+	return r, nil
+}
+
+// edge maps the position i to a valid position in [0, size), following the
+// edge mode. It returns false when the position should be read as black.
+func edge(i, size int, mode EdgeMode) (int, bool) {
+	if i >= 0 && i < size {
+		return i, true
+	}
+
+	switch mode {
+	case EdgeClamp:
+		return min(max(i, 0), size-1), true
+	case EdgeWrap:
+		return ((i % size) + size) % size, true
+	case EdgeMirror:
+		period := 2 * size
+		i = ((i % period) + period) % period
+		if i >= size {
+			i = period - 1 - i
+		}
+		return i, true
+	}
+
+	return 0, false
+}
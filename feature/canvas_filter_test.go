@@ -0,0 +1,212 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestNewKernel(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		height  int
+		weights []float64
+		err     error
+	}{
+		{
+			name:    "valid 1",
+			width:   1,
+			height:  1,
+			weights: []float64{1},
+			err:     nil,
+		},
+		{
+			name:    "valid 2",
+			width:   3,
+			height:  1,
+			weights: []float64{0.25, 0.5, 0.25},
+			err:     nil,
+		},
+		{
+			name:    "even size",
+			width:   2,
+			height:  1,
+			weights: []float64{0.5, 0.5},
+			err:     feature.ErrInvalidKernel,
+		},
+		{
+			name:    "wrong number of weights",
+			width:   3,
+			height:  3,
+			weights: []float64{1},
+			err:     feature.ErrInvalidKernel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := feature.NewKernel(test.width, test.height, test.weights)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+		})
+	}
+}
+
+func TestConvolve(t *testing.T) {
+	gray := feature.NewColor(0.5, 0.5, 0.5)
+	quarter := feature.NewColor(0.25, 0.25, 0.25)
+	rows := [][]feature.Tuple{
+		{white, black, black},
+	}
+
+	tests := []struct {
+		name string
+		mode feature.EdgeMode
+		want [][]feature.Tuple
+		err  error
+	}{
+		{
+			name: "clamp",
+			mode: feature.EdgeClamp,
+			want: [][]feature.Tuple{{feature.NewColor(0.75, 0.75, 0.75), quarter, black}},
+		},
+		{
+			name: "wrap",
+			mode: feature.EdgeWrap,
+			want: [][]feature.Tuple{{gray, quarter, quarter}},
+		},
+		{
+			name: "mirror",
+			mode: feature.EdgeMirror,
+			want: [][]feature.Tuple{{feature.NewColor(0.75, 0.75, 0.75), quarter, black}},
+		},
+		{
+			name: "zero",
+			mode: feature.EdgeZero,
+			want: [][]feature.Tuple{{gray, quarter, black}},
+		},
+		{
+			name: "invalid",
+			mode: feature.EdgeMode(42),
+			err:  feature.ErrInvalidEdgeMode,
+		},
+	}
+
+	k, err := feature.NewKernel(3, 1, []float64{0.25, 0.5, 0.25})
+	if err != nil {
+		t.Fatalf("error creating a kernel: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newCanvasFrom(t, rows).Convolve(k, test.mode)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			assertCanvas(t, test.name, got, test.want)
+		})
+	}
+}
+
+func TestGaussianBlur(t *testing.T) {
+	canvas := newCanvasFrom(t, [][]feature.Tuple{
+		{black, black, black, black, black},
+		{black, black, black, black, black},
+		{black, black, white, black, black},
+		{black, black, black, black, black},
+		{black, black, black, black, black},
+	})
+
+	got, err := canvas.GaussianBlur(0.8, feature.EdgeZero)
+	if err != nil {
+		t.Fatalf("error blurring: %v", err)
+	}
+
+	center, _ := got.Pixel(2, 2)
+	side, _ := got.Pixel(1, 2)
+	corner, _ := got.Pixel(1, 1)
+	if !(center.X > side.X && side.X > corner.X && corner.X > 0) {
+		t.Errorf("expected the blur to fall off from the center, got center %v, side %v and corner %v", center.X, side.X, corner.X)
+	}
+
+	total := 0.0
+	for y := range 5 {
+		for x := range 5 {
+			p, _ := got.Pixel(x, y)
+			total += p.X
+		}
+	}
+	if math.Abs(total-1) > 0.01 {
+		t.Errorf("expected the blur to keep the total energy 1, got %v", total)
+	}
+
+	if _, err := canvas.GaussianBlur(0, feature.EdgeZero); !errors.Is(err, feature.ErrInvalidSigma) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidSigma)
+	}
+}
+
+func TestSharpen(t *testing.T) {
+	gray := feature.NewColor(0.5, 0.5, 0.5)
+	canvas := newCanvasFrom(t, [][]feature.Tuple{
+		{gray, gray, gray},
+		{gray, gray, gray},
+	})
+
+	got, err := canvas.Sharpen(1, feature.EdgeClamp)
+	if err != nil {
+		t.Fatalf("error sharpening: %v", err)
+	}
+	assertCanvas(t, "flat", got, [][]feature.Tuple{
+		{gray, gray, gray},
+		{gray, gray, gray},
+	})
+
+	canvas = newCanvasFrom(t, [][]feature.Tuple{{black, gray, white}})
+	got, err = canvas.Sharpen(1, feature.EdgeClamp)
+	if err != nil {
+		t.Fatalf("error sharpening: %v", err)
+	}
+	assertCanvas(t, "edge", got, [][]feature.Tuple{
+		{feature.NewColor(-0.5, -0.5, -0.5), gray, feature.NewColor(1.5, 1.5, 1.5)},
+	})
+}
+
+func TestBloom(t *testing.T) {
+	hdr := feature.NewColor(4, 4, 4)
+	dim := feature.NewColor(0.5, 0.5, 0.5)
+	canvas := newCanvasFrom(t, [][]feature.Tuple{
+		{dim, dim, dim, dim, dim},
+		{dim, dim, hdr, dim, dim},
+		{dim, dim, dim, dim, dim},
+	})
+
+	got, err := canvas.Bloom(1, 1, 1)
+	if err != nil {
+		t.Fatalf("error applying bloom: %v", err)
+	}
+
+	neighbour, _ := got.Pixel(1, 1)
+	if neighbour.X <= dim.X {
+		t.Errorf("expected the bright pixel to glow over its neighbour, got %+v", neighbour)
+	}
+
+	// Without any pixel above the threshold, bloom keeps the canvas.
+	got, err = newCanvasFrom(t, [][]feature.Tuple{{dim, dim}}).Bloom(1, 1, 1)
+	if err != nil {
+		t.Fatalf("error applying bloom: %v", err)
+	}
+	assertCanvas(t, "dim", got, [][]feature.Tuple{{dim, dim}})
+
+	if _, err := canvas.Bloom(-0.5, 1, 1); !errors.Is(err, feature.ErrInvalidThreshold) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidThreshold)
+	}
+}
@@ -0,0 +1,163 @@
+package feature
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxImagePixels is the largest number of pixels of the images read by
+// ReadPPM and ReadFile, enough for a 8192x8192 image. It keeps a crafted
+// header from allocating a huge Canvas.
+const MaxImagePixels = 1 << 26
+
+var (
+	ErrUnknownImageFormat = errors.New("unknown image format")
+	ErrImageTooLarge      = errors.New("image too large")
+)
+
+// ToImage returns an 8 bits per channel RGBA version of the canvas, with
+// each color clamped to [0, 255].
+func (c *Canvas) ToImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, c.width, c.height))
+	for y := range c.height {
+		for x := range c.width {
+			i := y*c.width + x
+			p := c.pixels[i]
+			img.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(clamp(p.X, MaxColor)),
+				G: uint8(clamp(p.Y, MaxColor)),
+				B: uint8(clamp(p.Z, MaxColor)),
+				A: uint8(math.Round(c.alpha[i] * MaxColor)),
+			})
+		}
+	}
+
+	return img
+}
+
+// NewCanvasFromImage creates a new Canvas with the colors and the alpha of
+// img.
+func NewCanvasFromImage(img image.Image) (*Canvas, error) {
+	bounds := img.Bounds()
+	c, err := NewCanvas(bounds.Dx(), bounds.Dy())
+	if err != nil {
+		return nil, err
+	}
+
+	for y := range c.height {
+		for x := range c.width {
+			// RGBA returns 16 bits channels premultiplied by alpha.
+			r, g, b, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			i := y*c.width + x
+			c.pixels[i], c.alpha[i] = unpremultiply(Tuple{
+				X: float64(r) / 0xffff,
+				Y: float64(g) / 0xffff,
+				Z: float64(b) / 0xffff,
+				W: float64(a) / 0xffff,
+			})
+		}
+	}
+
+	return c, nil
+}
+
+// ToPNG writes an RGBA PNG version of the canvas to w.
+func (c *Canvas) ToPNG(w io.Writer) error {
+	return png.Encode(w, c.ToImage())
+}
+
+// checkImageSize returns an error if an image with width and height sizes
+// can't be read into a Canvas.
+func checkImageSize(width, height int) error {
+	if !validCanvasSize(width, height) {
+		return ErrInvalidCanvasSize
+	}
+	if width > MaxImagePixels/height {
+		return fmt.Errorf("%w: %dx%d is above %d pixels", ErrImageTooLarge, width, height, MaxImagePixels)
+	}
+
+	return nil
+}
+
+// ReadFile reads a Canvas from the PPM or PNG image file at path, following
+// the path extension.
+// It returns an error if the image has more than MaxImagePixels pixels.
+func ReadFile(path string) (*Canvas, error) {
+	var read func(r io.Reader) (*Canvas, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ppm":
+		read = ReadPPM
+	case ".png":
+		read = func(r io.Reader) (*Canvas, error) {
+			// The size is read from the header before decoding the pixels.
+			var header bytes.Buffer
+			config, err := png.DecodeConfig(io.TeeReader(r, &header))
+			if err != nil {
+				return nil, err
+			}
+			if err := checkImageSize(config.Width, config.Height); err != nil {
+				return nil, err
+			}
+
+			img, err := png.Decode(io.MultiReader(&header, r))
+			if err != nil {
+				return nil, err
+			}
+			return NewCanvasFromImage(img)
+		}
+	default:
+		return nil, ErrUnknownImageFormat
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return read(f)
+}
+
+// WriteFile writes the canvas to the file at path, as a PPM or a PNG image
+// following the path extension.
+func (c *Canvas) WriteFile(path string) error {
+	return writeImageFile(path, c.ToPPM, c.ToPNG)
+}
+
+// writeImageFile writes the file at path with toPPM or toPNG, following the
+// path extension.
+func writeImageFile(path string, toPPM func(identifier string, maxColor int) string, toPNG func(w io.Writer) error) error {
+	var write func(w io.Writer) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ppm":
+		write = func(w io.Writer) error {
+			_, err := io.WriteString(w, toPPM(IdentifierP3, MaxColor))
+			return err
+		}
+	case ".png":
+		write = toPNG
+	default:
+		return ErrUnknownImageFormat
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
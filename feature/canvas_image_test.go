@@ -0,0 +1,210 @@
+package feature_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"strings"
+	"testing"
+)
+
+func TestToPNG(t *testing.T) {
+	canvas, err := feature.NewCanvas(2, 1)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+	if err := canvas.WritePixel(0, 0, feature.NewColor(1.5, 0.5, -1.5)); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if err := canvas.WritePixelAlpha(1, 0, feature.ColorBlue, 0); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	buf := bytes.Buffer{}
+	if err := canvas.ToPNG(&buf); err != nil {
+		t.Fatalf("error encoding PNG: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("error decoding PNG: %v", err)
+	}
+
+	tests := []struct {
+		x    int
+		want color.NRGBA
+	}{
+		{x: 0, want: color.NRGBA{R: 255, G: 128, B: 0, A: 255}},
+		{x: 1, want: color.NRGBA{R: 0, G: 0, B: 255, A: 0}},
+	}
+	for _, test := range tests {
+		got := color.NRGBAModel.Convert(img.At(test.x, 0)).(color.NRGBA)
+		if got != test.want && !(test.want.A == 0 && got.A == 0) {
+			t.Errorf("pixel (%d, 0): wants %+v and got %+v", test.x, test.want, got)
+		}
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	canvas, err := feature.NewCanvas(2, 1)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+	canvas.Fill(feature.ColorRed)
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		file   string
+		prefix string
+		err    error
+	}{
+		{
+			name:   "ppm",
+			file:   "out.ppm",
+			prefix: "P3\n2 1\n255\n255 0 0 255 0 0\n",
+		},
+		{
+			name:   "png",
+			file:   "out.PNG",
+			prefix: "\x89PNG",
+		},
+		{
+			name: "unknown format",
+			file: "out.jpg",
+			err:  feature.ErrUnknownImageFormat,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			err := canvas.WriteFile(path)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%q: error reading the file: %v", test.name, err)
+			}
+			if !strings.HasPrefix(string(data), test.prefix) {
+				t.Errorf("%q: wants a file starting with %q and got %q", test.name, test.prefix, data)
+			}
+		})
+	}
+}
+
+func TestNewCanvasFromImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 20, 12, 21))
+	img.SetNRGBA(10, 20, color.NRGBA{R: 255, G: 0, B: 0, A: 255})
+	img.SetNRGBA(11, 20, color.NRGBA{R: 0, G: 0, B: 255, A: 51})
+
+	c, err := feature.NewCanvasFromImage(img)
+	if err != nil {
+		t.Fatalf("error creating the canvas: %v", err)
+	}
+
+	tests := []struct {
+		x     int
+		color feature.Tuple
+		alpha float64
+	}{
+		{x: 0, color: feature.ColorRed, alpha: 1},
+		{x: 1, color: feature.ColorBlue, alpha: 0.2},
+	}
+	for _, test := range tests {
+		got, alpha, err := c.PixelAlpha(test.x, 0)
+		if err != nil {
+			t.Fatalf("error reading pixel: %v", err)
+		}
+		if !test.color.IsEqual(got) || math.Abs(alpha-test.alpha) > 0.00001 {
+			t.Errorf("pixel (%d, 0): wants %v with alpha %f and got %v with alpha %f", test.x, test.color, test.alpha, got, alpha)
+		}
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	canvas, err := feature.NewCanvas(2, 1)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+	canvas.Fill(feature.ColorRed)
+	if err := canvas.WritePixel(1, 0, feature.ColorBlue); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		file string
+		err  error
+	}{
+		{name: "ppm", file: "in.ppm"},
+		{name: "png", file: "in.png"},
+		{name: "unknown format", file: "in.jpg", err: feature.ErrUnknownImageFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			if test.err == nil {
+				if err := canvas.WriteFile(path); err != nil {
+					t.Fatalf("%q: error writing the file: %v", test.name, err)
+				}
+			}
+
+			got, err := feature.ReadFile(path)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			assertCanvas(t, test.name, got, [][]feature.Tuple{{feature.ColorRed, feature.ColorBlue}})
+		})
+	}
+}
+
+func TestReadFileTooLarge(t *testing.T) {
+	canvas, err := feature.NewCanvas(1, 1)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := canvas.ToPNG(&buf); err != nil {
+		t.Fatalf("error writing the PNG: %v", err)
+	}
+
+	// The IHDR chunk follows the 8 bytes signature: length, type, width,
+	// height and the rest of its data, then its CRC.
+	data := buf.Bytes()
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	path := filepath.Join(t.TempDir(), "huge.png")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("error writing the file: %v", err)
+	}
+
+	if _, err := feature.ReadFile(path); !errors.Is(err, feature.ErrImageTooLarge) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrImageTooLarge)
+	}
+}
@@ -0,0 +1,257 @@
+package feature
+
+import (
+	"errors"
+	"math"
+)
+
+// Filter is the resampling filter used to resize a Canvas.
+type Filter int
+
+const (
+	FilterNearest Filter = iota
+	FilterBilinear
+	FilterLanczos
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid resize filter")
+	ErrInvalidAlpha  = errors.New("invalid alpha")
+)
+
+// Crop returns a new Canvas with a copy of the area with width and height
+// sizes starting at the position x and y.
+func (c *Canvas) Crop(x, y, width, height int) (*Canvas, error) {
+	s, err := c.SubCanvas(x, y, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	r := blankCanvas(width, height)
+	for row := range height {
+		start := (s.y+row)*c.width + s.x
+		copy(r.pixels[row*width:(row+1)*width], c.pixels[start:start+width])
+		copy(r.alpha[row*width:(row+1)*width], c.alpha[start:start+width])
+	}
+
+	return r, nil
+}
+
+// Resize returns a new Canvas with width and height sizes, resampling the
+// pixels with the given filter.
+func (c *Canvas) Resize(width, height int, filter Filter) (*Canvas, error) {
+	if !validCanvasSize(width, height) {
+		return nil, ErrInvalidCanvasSize
+	}
+
+	switch filter {
+	case FilterNearest:
+		return c.resizeNearest(width, height), nil
+	case FilterBilinear:
+		return c.resample(width, height, triangleKernel, 1), nil
+	case FilterLanczos:
+		return c.resample(width, height, lanczosKernel, 3), nil
+	}
+
+	return nil, ErrInvalidFilter
+}
+
+// Blit draws src over the Canvas with its top left corner at the position x
+// and y, scaling the src alpha by alpha (0 keeps the Canvas, 1 draws src
+// with its own alpha). The parts of src out of the Canvas bounds are ignored.
+func (c *Canvas) Blit(src *Canvas, x, y int, alpha float64) error {
+	if !(alpha >= 0 && alpha <= 1) {
+		return ErrInvalidAlpha
+	}
+
+	c.composite(src, x, y, CompositeOver, alpha)
+
+	return nil
+}
+
+// FlipHorizontal returns a new Canvas mirrored from left to right.
+func (c *Canvas) FlipHorizontal() *Canvas {
+	return c.remap(c.width, c.height, func(x, y int) (int, int) {
+		return c.width - 1 - x, y
+	})
+}
+
+// FlipVertical returns a new Canvas mirrored from top to bottom.
+func (c *Canvas) FlipVertical() *Canvas {
+	return c.remap(c.width, c.height, func(x, y int) (int, int) {
+		return x, c.height - 1 - y
+	})
+}
+
+// RotateClockwise returns a new Canvas rotated by 90 degrees clockwise.
+func (c *Canvas) RotateClockwise() *Canvas {
+	return c.remap(c.height, c.width, func(x, y int) (int, int) {
+		return y, c.height - 1 - x
+	})
+}
+
+// RotateCounterClockwise returns a new Canvas rotated by 90 degrees
+// counterclockwise.
+func (c *Canvas) RotateCounterClockwise() *Canvas {
+	return c.remap(c.height, c.width, func(x, y int) (int, int) {
+		return c.width - 1 - y, x
+	})
+}
+
+// Rotate180 returns a new Canvas rotated by 180 degrees.
+func (c *Canvas) Rotate180() *Canvas {
+	return c.remap(c.width, c.height, func(x, y int) (int, int) {
+		return c.width - 1 - x, c.height - 1 - y
+	})
+}
+
+// remap returns a new Canvas where each pixel x and y comes from the
+// position returned by src.
+func (c *Canvas) remap(width, height int, src func(x, y int) (int, int)) *Canvas {
+	r := blankCanvas(width, height)
+	for y := range height {
+		for x := range width {
+			sx, sy := src(x, y)
+			r.pixels[y*width+x] = c.pixels[sy*c.width+sx]
+			r.alpha[y*width+x] = c.alpha[sy*c.width+sx]
+		}
+	}
+
+	return r
+}
+
+func (c *Canvas) resizeNearest(width, height int) *Canvas {
+	return c.remap(width, height, func(x, y int) (int, int) {
+		sx := min(int((float64(x)+0.5)*float64(c.width)/float64(width)), c.width-1)
+		sy := min(int((float64(y)+0.5)*float64(c.height)/float64(height)), c.height-1)
+		return sx, sy
+	})
+}
+
+// resample resizes the Canvas with a separable filter, first horizontally
+// and then vertically. The kernel is stretched when downsampling, so every
+// source pixel contributes to the result. Colors are filtered premultiplied
+// by alpha, so transparent pixels don't bleed into their neighbours.
+func (c *Canvas) resample(width, height int, kernel func(float64) float64, support float64) *Canvas {
+	// The alpha travels in W while filtering.
+	premultiplied := make([]Tuple, len(c.pixels))
+	for i, p := range c.pixels {
+		a := c.alpha[i]
+		premultiplied[i] = Tuple{X: p.X * a, Y: p.Y * a, Z: p.Z * a, W: a}
+	}
+
+	horizontal := make([]Tuple, width*c.height)
+	weights := resampleWeights(c.width, width, kernel, support)
+	for y := range c.height {
+		for x, ws := range weights {
+			horizontal[y*width+x] = ws.apply(func(i int) Tuple {
+				return premultiplied[y*c.width+i]
+			})
+		}
+	}
+
+	r := blankCanvas(width, height)
+	weights = resampleWeights(c.height, height, kernel, support)
+	for y, ws := range weights {
+		for x := range width {
+			p := ws.apply(func(i int) Tuple {
+				return horizontal[i*width+x]
+			})
+			r.pixels[y*width+x], r.alpha[y*width+x] = unpremultiply(p)
+		}
+	}
+
+	return r
+}
+
+type resampleWeight struct {
+	first   int
+	weights []float64
+}
+
+func (w resampleWeight) apply(pixel func(int) Tuple) Tuple {
+	var r Tuple
+	for i, weight := range w.weights {
+		p := pixel(w.first + i)
+		r.X += p.X * weight
+		r.Y += p.Y * weight
+		r.Z += p.Z * weight
+		r.W += p.W * weight
+	}
+
+	return r
+}
+
+// resampleWeights returns, for each destination position, the normalized
+// kernel weights of the source positions around it.
+func resampleWeights(src, dst int, kernel func(float64) float64, support float64) []resampleWeight {
+	scale := float64(src) / float64(dst)
+	stretch := math.Max(scale, 1)
+	radius := support * stretch
+
+	ws := make([]resampleWeight, dst)
+	for i := range dst {
+		center := (float64(i)+0.5)*scale - 0.5
+		first := max(int(math.Ceil(center-radius)), 0)
+		last := min(int(math.Floor(center+radius)), src-1)
+
+		w := resampleWeight{first: first}
+		sum := 0.0
+		for j := first; j <= last; j++ {
+			k := kernel((float64(j) - center) / stretch)
+			w.weights = append(w.weights, k)
+			sum += k
+		}
+
+		if sum == 0 {
+			nearest := min(max(int(math.Round(center)), 0), src-1)
+			w = resampleWeight{first: nearest, weights: []float64{1}}
+		} else {
+			for j := range w.weights {
+				w.weights[j] /= sum
+			}
+		}
+
+		ws[i] = w
+	}
+
+	return ws
+}
+
+func triangleKernel(x float64) float64 {
+	x = math.Abs(x)
+	if x >= 1 {
+		return 0
+	}
+
+	return 1 - x
+}
+
+func lanczosKernel(x float64) float64 {
+	const a = 3
+
+	x = math.Abs(x)
+	if x >= a {
+		return 0
+	}
+
+	return sinc(x) * sinc(x/a)
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+
+	x *= math.Pi
+
+	return math.Sin(x) / x
+}
+
+// blankCanvas creates a Canvas with valid sizes where each pixel is opaque
+// black.
+func blankCanvas(width, height int) *Canvas {
+	c, _ := NewCanvas(width, height)
+
+	return c
+}
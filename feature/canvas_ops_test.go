@@ -0,0 +1,341 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+// newCanvasFrom creates a canvas with the pixels of rows, one slice per row.
+func newCanvasFrom(t *testing.T, rows [][]feature.Tuple) *feature.Canvas {
+	t.Helper()
+
+	canvas, err := feature.NewCanvas(len(rows[0]), len(rows))
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	for y, row := range rows {
+		for x, pixel := range row {
+			if err := canvas.WritePixel(x, y, pixel); err != nil {
+				t.Fatalf("error writing pixel: %v", err)
+			}
+		}
+	}
+
+	return canvas
+}
+
+// assertCanvas checks that every pixel of canvas matches rows.
+func assertCanvas(t *testing.T, name string, canvas *feature.Canvas, rows [][]feature.Tuple) {
+	t.Helper()
+
+	if canvas.Width() != len(rows[0]) || canvas.Height() != len(rows) {
+		t.Fatalf("%q: got a canvas %dx%d, expected %dx%d", name, canvas.Width(), canvas.Height(), len(rows[0]), len(rows))
+	}
+
+	for y, row := range rows {
+		for x, want := range row {
+			got, err := canvas.Pixel(x, y)
+			if err != nil {
+				t.Fatalf("%q: error reading pixel: %v", name, err)
+			}
+			if !got.IsEqual(want) {
+				t.Errorf("%q: pixel (%d, %d) wants %+v and got %+v", name, x, y, want, got)
+			}
+		}
+	}
+}
+
+var (
+	red   = feature.ColorRed
+	green = feature.ColorGreen
+	blue  = feature.ColorBlue
+	white = feature.ColorWhite
+	black = feature.ColorBlack
+)
+
+func TestCrop(t *testing.T) {
+	canvas := newCanvasFrom(t, [][]feature.Tuple{
+		{red, green, blue},
+		{white, black, red},
+	})
+
+	got, err := canvas.Crop(1, 0, 2, 2)
+	if err != nil {
+		t.Fatalf("error cropping: %v", err)
+	}
+	assertCanvas(t, "crop", got, [][]feature.Tuple{
+		{green, blue},
+		{black, red},
+	})
+
+	// The crop is a copy, so it doesn't change the original canvas.
+	if err := got.WritePixel(0, 0, white); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if p, _ := canvas.Pixel(1, 0); !p.IsEqual(green) {
+		t.Errorf("expected the original pixel to be %v but got %+v", green, p)
+	}
+
+	if _, err := canvas.Crop(2, 0, 2, 2); !errors.Is(err, feature.ErrInvalidCanvasArea) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidCanvasArea)
+	}
+}
+
+func TestFlipAndRotate(t *testing.T) {
+	canvas := newCanvasFrom(t, [][]feature.Tuple{
+		{red, green, blue},
+		{white, black, red},
+	})
+
+	tests := []struct {
+		name string
+		got  *feature.Canvas
+		want [][]feature.Tuple
+	}{
+		{
+			name: "flip horizontal",
+			got:  canvas.FlipHorizontal(),
+			want: [][]feature.Tuple{
+				{blue, green, red},
+				{red, black, white},
+			},
+		},
+		{
+			name: "flip vertical",
+			got:  canvas.FlipVertical(),
+			want: [][]feature.Tuple{
+				{white, black, red},
+				{red, green, blue},
+			},
+		},
+		{
+			name: "rotate clockwise",
+			got:  canvas.RotateClockwise(),
+			want: [][]feature.Tuple{
+				{white, red},
+				{black, green},
+				{red, blue},
+			},
+		},
+		{
+			name: "rotate counterclockwise",
+			got:  canvas.RotateCounterClockwise(),
+			want: [][]feature.Tuple{
+				{blue, red},
+				{green, black},
+				{red, white},
+			},
+		},
+		{
+			name: "rotate 180",
+			got:  canvas.Rotate180(),
+			want: [][]feature.Tuple{
+				{red, black, white},
+				{blue, green, red},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertCanvas(t, test.name, test.got, test.want)
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	gray := feature.NewColor(0.5, 0.5, 0.5)
+
+	tests := []struct {
+		name   string
+		rows   [][]feature.Tuple
+		width  int
+		height int
+		filter feature.Filter
+		want   [][]feature.Tuple
+		err    error
+	}{
+		{
+			name:   "nearest upscale",
+			rows:   [][]feature.Tuple{{red, green}},
+			width:  4,
+			height: 2,
+			filter: feature.FilterNearest,
+			want: [][]feature.Tuple{
+				{red, red, green, green},
+				{red, red, green, green},
+			},
+		},
+		{
+			name:   "nearest downscale",
+			rows:   [][]feature.Tuple{{red, red, green, green}},
+			width:  2,
+			height: 1,
+			filter: feature.FilterNearest,
+			want:   [][]feature.Tuple{{red, green}},
+		},
+		{
+			name:   "bilinear downscale",
+			rows:   [][]feature.Tuple{{black, white}, {black, white}},
+			width:  1,
+			height: 1,
+			filter: feature.FilterBilinear,
+			want:   [][]feature.Tuple{{gray}},
+		},
+		{
+			name:   "bilinear keeps a flat color",
+			rows:   [][]feature.Tuple{{blue, blue}, {blue, blue}},
+			width:  5,
+			height: 3,
+			filter: feature.FilterBilinear,
+			want: [][]feature.Tuple{
+				{blue, blue, blue, blue, blue},
+				{blue, blue, blue, blue, blue},
+				{blue, blue, blue, blue, blue},
+			},
+		},
+		{
+			name:   "lanczos keeps a flat color",
+			rows:   [][]feature.Tuple{{green, green, green}, {green, green, green}},
+			width:  2,
+			height: 4,
+			filter: feature.FilterLanczos,
+			want: [][]feature.Tuple{
+				{green, green},
+				{green, green},
+				{green, green},
+				{green, green},
+			},
+		},
+		{
+			name:   "same size",
+			rows:   [][]feature.Tuple{{red, green}, {blue, white}},
+			width:  2,
+			height: 2,
+			filter: feature.FilterLanczos,
+			want:   [][]feature.Tuple{{red, green}, {blue, white}},
+		},
+		{
+			name:   "invalid size",
+			rows:   [][]feature.Tuple{{red}},
+			width:  0,
+			height: 1,
+			filter: feature.FilterNearest,
+			err:    feature.ErrInvalidCanvasSize,
+		},
+		{
+			name:   "invalid filter",
+			rows:   [][]feature.Tuple{{red}},
+			width:  1,
+			height: 1,
+			filter: feature.Filter(-1),
+			err:    feature.ErrInvalidFilter,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := newCanvasFrom(t, test.rows).Resize(test.width, test.height, test.filter)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			assertCanvas(t, test.name, got, test.want)
+		})
+	}
+}
+
+func TestBlit(t *testing.T) {
+	half := feature.NewColor(0.5, 0, 0.5)
+
+	tests := []struct {
+		name  string
+		x     int
+		y     int
+		alpha float64
+		want  [][]feature.Tuple
+		err   error
+	}{
+		{
+			name:  "opaque",
+			x:     1,
+			y:     1,
+			alpha: 1,
+			want: [][]feature.Tuple{
+				{blue, blue, blue},
+				{blue, red, red},
+				{blue, red, red},
+			},
+		},
+		{
+			name:  "half transparent",
+			x:     0,
+			y:     0,
+			alpha: 0.5,
+			want: [][]feature.Tuple{
+				{half, half, blue},
+				{half, half, blue},
+				{blue, blue, blue},
+			},
+		},
+		{
+			name:  "clipped",
+			x:     -1,
+			y:     2,
+			alpha: 1,
+			want: [][]feature.Tuple{
+				{blue, blue, blue},
+				{blue, blue, blue},
+				{red, blue, blue},
+			},
+		},
+		{
+			name:  "invalid alpha",
+			alpha: 1.5,
+			want: [][]feature.Tuple{
+				{blue, blue, blue},
+				{blue, blue, blue},
+				{blue, blue, blue},
+			},
+			err: feature.ErrInvalidAlpha,
+		},
+		{
+			name:  "NaN alpha",
+			alpha: math.NaN(),
+			want: [][]feature.Tuple{
+				{blue, blue, blue},
+				{blue, blue, blue},
+				{blue, blue, blue},
+			},
+			err: feature.ErrInvalidAlpha,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := newCanvasFrom(t, [][]feature.Tuple{
+				{blue, blue, blue},
+				{blue, blue, blue},
+				{blue, blue, blue},
+			})
+			src := newCanvasFrom(t, [][]feature.Tuple{
+				{red, red},
+				{red, red},
+			})
+
+			err := dst.Blit(src, test.x, test.y, test.alpha)
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+
+			assertCanvas(t, test.name, dst, test.want)
+		})
+	}
+}
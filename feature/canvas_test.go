@@ -2,6 +2,7 @@ package feature_test
 
 import (
 	"errors"
+	"math"
 	"ray-tracer/feature"
 	"testing"
 )
@@ -31,6 +32,12 @@ func TestNewCanvas(t *testing.T) {
 			height: 1,
 			err:    feature.ErrInvalidCanvasSize,
 		},
+		{
+			name:   "overflow",
+			width:  1 << 32,
+			height: 1 << 32,
+			err:    feature.ErrInvalidCanvasSize,
+		},
 	}
 
 	for _, test := range tests {
@@ -142,6 +149,97 @@ func TestWritePixel(t *testing.T) {
 	}
 }
 
+func TestWritePixelAlpha(t *testing.T) {
+	tests := []struct {
+		name  string
+		alpha float64
+		err   error
+	}{
+		{
+			name:  "transparent",
+			alpha: 0,
+			err:   nil,
+		},
+		{
+			name:  "half",
+			alpha: 0.5,
+			err:   nil,
+		},
+		{
+			name:  "invalid 1",
+			alpha: -0.1,
+			err:   feature.ErrInvalidAlpha,
+		},
+		{
+			name:  "invalid 2",
+			alpha: 1.1,
+			err:   feature.ErrInvalidAlpha,
+		},
+		{
+			name:  "NaN",
+			alpha: math.NaN(),
+			err:   feature.ErrInvalidAlpha,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			canvas, err := feature.NewCanvas(2, 2)
+			if err != nil {
+				t.Fatalf("error creating a new canvas: %v", err)
+			}
+
+			err = canvas.WritePixelAlpha(1, 0, feature.ColorRed, test.alpha)
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, alpha, err := canvas.PixelAlpha(1, 0)
+			if err != nil {
+				t.Fatalf("%q: error reading pixel: %v", test.name, err)
+			}
+			if !got.IsEqual(feature.ColorRed) || alpha != test.alpha {
+				t.Errorf("%q: expected pixel %v with alpha %f but got %+v with alpha %f", test.name, feature.ColorRed, test.alpha, got, alpha)
+			}
+
+			// Writing a color without alpha makes the pixel opaque again.
+			if err := canvas.WritePixel(1, 0, feature.ColorBlue); err != nil {
+				t.Fatalf("%q: error writing pixel: %v", test.name, err)
+			}
+			if _, alpha, _ := canvas.PixelAlpha(1, 0); alpha != 1 {
+				t.Errorf("%q: expected an opaque pixel but got alpha %f", test.name, alpha)
+			}
+		})
+	}
+}
+
+func TestPixelNonSquareCanvas(t *testing.T) {
+	canvas, err := feature.NewCanvas(4, 2)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	if err := canvas.WritePixel(3, 0, feature.ColorRed); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if err := canvas.WritePixel(0, 1, feature.ColorGreen); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	want := `P3
+4 2
+255
+0 0 0 0 0 0 0 0 0 255 0 0 0 255 0 0 0 0 0 0 0 0 0 0
+`
+	got := canvas.ToPPM(feature.IdentifierP3, feature.MaxColor)
+	if got != want {
+		t.Errorf("got PPM %q, expected %q", got, want)
+	}
+}
+
 var (
 	ppm1 = `P3
 1 1
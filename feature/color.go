@@ -0,0 +1,48 @@
+package feature
+
+// Color is a red, green and blue color.
+type Color struct {
+	R float64
+	G float64
+	B float64
+}
+
+// RGB creates a new Color.
+func RGB(r, g, b float64) Color {
+	return Color{R: r, G: g, B: b}
+}
+
+// Color converts the Tuple to a Color.
+func (t Tuple) Color() Color {
+	return Color{R: t.X, G: t.Y, B: t.Z}
+}
+
+// Tuple converts the Color to a color Tuple.
+func (c Color) Tuple() Tuple {
+	return NewColor(c.R, c.G, c.B)
+}
+
+// IsEqual returns if both Colors are the same.
+func (c Color) IsEqual(o Color) bool {
+	return isEqual(c.R, o.R) && isEqual(c.G, o.G) && isEqual(c.B, o.B)
+}
+
+// Add sums two Colors and returns a new Color.
+func (c Color) Add(o Color) Color {
+	return Color{R: c.R + o.R, G: c.G + o.G, B: c.B + o.B}
+}
+
+// Sub subtracts two Colors and returns a new Color.
+func (c Color) Sub(o Color) Color {
+	return Color{R: c.R - o.R, G: c.G - o.G, B: c.B - o.B}
+}
+
+// Mul is the multiplication of the Color by a scalar.
+func (c Color) Mul(s float64) Color {
+	return Color{R: c.R * s, G: c.G * s, B: c.B * s}
+}
+
+// HadamardProduct is the multiplication of the Color by other Color.
+func (c Color) HadamardProduct(o Color) Color {
+	return Color{R: c.R * o.R, G: c.G * o.G, B: c.B * o.B}
+}
@@ -0,0 +1,195 @@
+package feature
+
+import "math"
+
+// whiteD65 is the CIE XYZ of the D65 white point, the white of sRGB.
+var whiteD65 = XYZ{X: 0.95047, Y: 1.0, Z: 1.08883}
+
+// XYZ is a color in the CIE 1931 XYZ color space, relative to the D65 white
+// point, where Y is the luminance.
+type XYZ struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Lab is a color in the CIE L*a*b* color space, relative to the D65 white
+// point, where L is the perceptual lightness in [0, 100], A goes from green
+// to red and B goes from blue to yellow.
+type Lab struct {
+	L float64
+	A float64
+	B float64
+}
+
+// XYZ converts a linear RGB color, with the sRGB primaries, to CIE XYZ.
+func (t Tuple) XYZ() XYZ {
+	return XYZ{
+		X: 0.4124564*t.X + 0.3575761*t.Y + 0.1804375*t.Z,
+		Y: 0.2126729*t.X + 0.7151522*t.Y + 0.0721750*t.Z,
+		Z: 0.0193339*t.X + 0.1191920*t.Y + 0.9503041*t.Z,
+	}
+}
+
+// RGB converts the color to a linear RGB color, with the sRGB primaries.
+func (c XYZ) RGB() Tuple {
+	return NewColor(
+		3.2404542*c.X-1.5371385*c.Y-0.4985314*c.Z,
+		-0.9692660*c.X+1.8760108*c.Y+0.0415560*c.Z,
+		0.0556434*c.X-0.2040259*c.Y+1.0572252*c.Z,
+	)
+}
+
+// Lab converts the color to CIE L*a*b*.
+func (c XYZ) Lab() Lab {
+	const (
+		epsilon = 216.0 / 24389.0
+		kappa   = 24389.0 / 27.0
+	)
+
+	f := func(t float64) float64 {
+		if t > epsilon {
+			return math.Cbrt(t)
+		}
+
+		return (kappa*t + 16) / 116
+	}
+
+	fx := f(c.X / whiteD65.X)
+	fy := f(c.Y / whiteD65.Y)
+	fz := f(c.Z / whiteD65.Z)
+
+	return Lab{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+// XYZ converts the color to CIE XYZ.
+func (c Lab) XYZ() XYZ {
+	const (
+		epsilon = 216.0 / 24389.0
+		kappa   = 24389.0 / 27.0
+	)
+
+	fy := (c.L + 16) / 116
+	fx := fy + c.A/500
+	fz := fy - c.B/200
+
+	f := func(t float64) float64 {
+		if t3 := t * t * t; t3 > epsilon {
+			return t3
+		}
+
+		return (116*t - 16) / kappa
+	}
+
+	y := c.L / kappa
+	if c.L > kappa*epsilon {
+		y = fy * fy * fy
+	}
+
+	return XYZ{
+		X: f(fx) * whiteD65.X,
+		Y: y * whiteD65.Y,
+		Z: f(fz) * whiteD65.Z,
+	}
+}
+
+// Lab converts a linear RGB color, with the sRGB primaries, to CIE L*a*b*.
+func (t Tuple) Lab() Lab {
+	return t.XYZ().Lab()
+}
+
+// RGB converts the color to a linear RGB color, with the sRGB primaries.
+func (c Lab) RGB() Tuple {
+	return c.XYZ().RGB()
+}
+
+// DeltaE is the CIEDE2000 perceptual difference between two linear RGB
+// colors. A difference below 1.0 is not noticeable by the human eye.
+func (t Tuple) DeltaE(o Tuple) float64 {
+	return DeltaE2000(t.Lab(), o.Lab())
+}
+
+// DeltaE2000 is the CIEDE2000 perceptual difference between two colors.
+func DeltaE2000(c1, c2 Lab) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	deg := func(rad float64) float64 { return rad * 180 / math.Pi }
+
+	cab := (math.Hypot(c1.A, c1.B) + math.Hypot(c2.A, c2.B)) / 2
+	cab7 := math.Pow(cab, 7)
+	g := 0.5 * (1 - math.Sqrt(cab7/(cab7+pow25to7)))
+
+	a1 := (1 + g) * c1.A
+	a2 := (1 + g) * c2.A
+	cp1 := math.Hypot(a1, c1.B)
+	cp2 := math.Hypot(a2, c2.B)
+
+	hp := func(a, b float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+
+		h := deg(math.Atan2(b, a))
+		if h < 0 {
+			h += 360
+		}
+
+		return h
+	}
+	hp1 := hp(a1, c1.B)
+	hp2 := hp(a2, c2.B)
+
+	dL := c2.L - c1.L
+	dC := cp2 - cp1
+
+	dh := 0.0
+	if cp1*cp2 != 0 {
+		dh = hp2 - hp1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(cp1*cp2) * math.Sin(rad(dh/2))
+
+	lMean := (c1.L + c2.L) / 2
+	cMean := (cp1 + cp2) / 2
+
+	hMean := hp1 + hp2
+	if cp1*cp2 != 0 {
+		if math.Abs(hp1-hp2) <= 180 {
+			hMean /= 2
+		} else if hMean < 360 {
+			hMean = (hMean + 360) / 2
+		} else {
+			hMean = (hMean - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos(rad(hMean-30)) +
+		0.24*math.Cos(rad(2*hMean)) +
+		0.32*math.Cos(rad(3*hMean+6)) -
+		0.20*math.Cos(rad(4*hMean-63))
+
+	dTheta := 30 * math.Exp(-math.Pow((hMean-275)/25, 2))
+	cMean7 := math.Pow(cMean, 7)
+	rc := 2 * math.Sqrt(cMean7/(cMean7+pow25to7))
+	l50 := (lMean - 50) * (lMean - 50)
+	sl := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sc := 1 + 0.045*cMean
+	sh := 1 + 0.015*cMean*t
+	rt := -math.Sin(rad(2*dTheta)) * rc
+
+	return math.Sqrt(
+		math.Pow(dL/sl, 2) +
+			math.Pow(dC/sc, 2) +
+			math.Pow(dH/sh, 2) +
+			rt*(dC/sc)*(dH/sh),
+	)
+}
@@ -0,0 +1,138 @@
+package feature_test
+
+import (
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestXYZAndLab(t *testing.T) {
+	tests := []struct {
+		name  string
+		color feature.Tuple
+		xyz   feature.XYZ
+		lab   feature.Lab
+	}{
+		{
+			name:  "black",
+			color: feature.ColorBlack,
+			xyz:   feature.XYZ{X: 0, Y: 0, Z: 0},
+			lab:   feature.Lab{L: 0, A: 0, B: 0},
+		},
+		{
+			name:  "white",
+			color: feature.ColorWhite,
+			xyz:   feature.XYZ{X: 0.95047, Y: 1, Z: 1.08883},
+			lab:   feature.Lab{L: 100, A: 0, B: 0},
+		},
+		{
+			name:  "red",
+			color: feature.ColorRed,
+			xyz:   feature.XYZ{X: 0.41246, Y: 0.21267, Z: 0.01933},
+			lab:   feature.Lab{L: 53.2408, A: 80.0925, B: 67.2032},
+		},
+		{
+			name:  "blue",
+			color: feature.ColorBlue,
+			xyz:   feature.XYZ{X: 0.18044, Y: 0.07218, Z: 0.95030},
+			lab:   feature.Lab{L: 32.2970, A: 79.1875, B: -107.8602},
+		},
+	}
+
+	const tolerance = 0.001
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			xyz := test.color.XYZ()
+			if math.Abs(xyz.X-test.xyz.X) > tolerance || math.Abs(xyz.Y-test.xyz.Y) > tolerance || math.Abs(xyz.Z-test.xyz.Z) > tolerance {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.xyz, xyz)
+			}
+
+			lab := test.color.Lab()
+			if math.Abs(lab.L-test.lab.L) > 0.01 || math.Abs(lab.A-test.lab.A) > 0.01 || math.Abs(lab.B-test.lab.B) > 0.01 {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.lab, lab)
+			}
+
+			if back := lab.RGB(); !back.IsEqualTol(test.color, feature.Tolerance{Abs: tolerance}) {
+				t.Errorf("%s wants %+v back and got %+v", test.name, test.color, back)
+			}
+		})
+	}
+
+	// Dark colors use the linear part of the L*a*b* curve.
+	dark := feature.NewColor(0.001, 0.002, 0.0005)
+	if back := dark.Lab().RGB(); !back.IsEqualTol(dark, feature.Tolerance{Abs: 1e-9}) {
+		t.Errorf("dark wants %+v back and got %+v", dark, back)
+	}
+}
+
+func TestDeltaE2000(t *testing.T) {
+	// Test data from Sharma, Wu and Dalal, "The CIEDE2000 Color-Difference
+	// Formula: Implementation Notes, Supplementary Test Data, and
+	// Mathematical Observations".
+	tests := []struct {
+		name string
+		a    feature.Lab
+		b    feature.Lab
+		want float64
+	}{
+		{
+			name: "pair 1",
+			a:    feature.Lab{L: 50, A: 2.6772, B: -79.7751},
+			b:    feature.Lab{L: 50, A: 0, B: -82.7485},
+			want: 2.0425,
+		},
+		{
+			name: "pair 7",
+			a:    feature.Lab{L: 50, A: 0, B: 0},
+			b:    feature.Lab{L: 50, A: -1, B: 2},
+			want: 2.3669,
+		},
+		{
+			name: "pair 13",
+			a:    feature.Lab{L: 50, A: 2.49, B: -0.001},
+			b:    feature.Lab{L: 50, A: -2.49, B: 0.0011},
+			want: 7.2195,
+		},
+		{
+			name: "pair 17",
+			a:    feature.Lab{L: 50, A: 2.5, B: 0},
+			b:    feature.Lab{L: 73, A: 25, B: -18},
+			want: 27.1492,
+		},
+		{
+			name: "pair 25",
+			a:    feature.Lab{L: 60.2574, A: -34.0099, B: 36.2677},
+			b:    feature.Lab{L: 60.4626, A: -34.1751, B: 39.4387},
+			want: 1.2644,
+		},
+		{
+			name: "same",
+			a:    feature.Lab{L: 42, A: 10, B: -10},
+			b:    feature.Lab{L: 42, A: 10, B: -10},
+			want: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := feature.DeltaE2000(test.a, test.b); math.Abs(got-test.want) > 0.0001 {
+				t.Errorf("%s wants %f and got %f", test.name, test.want, got)
+			}
+			if got := feature.DeltaE2000(test.b, test.a); math.Abs(got-test.want) > 0.0001 {
+				t.Errorf("%s wants a symmetric %f and got %f", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	gray := feature.NewColor(0.5, 0.5, 0.5)
+
+	if got := gray.DeltaE(feature.NewColor(0.501, 0.5, 0.5)); got >= 1 {
+		t.Errorf("expected an unnoticeable difference, got %f", got)
+	}
+	if got := gray.DeltaE(feature.NewColor(0.6, 0.5, 0.5)); got <= 1 {
+		t.Errorf("expected a noticeable difference, got %f", got)
+	}
+}
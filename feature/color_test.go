@@ -0,0 +1,55 @@
+package feature_test
+
+import (
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestColorOperations(t *testing.T) {
+	a := feature.RGB(0.9, 0.6, 0.75)
+	b := feature.RGB(0.7, 0.1, 0.25)
+
+	tests := []struct {
+		name string
+		got  feature.Color
+		want feature.Color
+	}{
+		{
+			name: "add",
+			got:  a.Add(b),
+			want: feature.RGB(1.6, 0.7, 1.0),
+		},
+		{
+			name: "sub",
+			got:  a.Sub(b),
+			want: feature.RGB(0.2, 0.5, 0.5),
+		},
+		{
+			name: "mul",
+			got:  feature.RGB(0.2, 0.3, 0.4).Mul(2),
+			want: feature.RGB(0.4, 0.6, 0.8),
+		},
+		{
+			name: "hadamard product",
+			got:  feature.RGB(1, 0.2, 0.4).HadamardProduct(feature.RGB(0.9, 1, 0.1)),
+			want: feature.RGB(0.9, 0.2, 0.04),
+		},
+		{
+			name: "from tuple",
+			got:  feature.NewColor(0.1, 0.2, 0.3).Color(),
+			want: feature.RGB(0.1, 0.2, 0.3),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.want.IsEqual(test.got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, test.got)
+			}
+		})
+	}
+
+	if got := a.Tuple(); !got.IsEqual(feature.NewColor(0.9, 0.6, 0.75)) {
+		t.Errorf("to tuple wants %+v and got %+v", feature.NewColor(0.9, 0.6, 0.75), got)
+	}
+}
@@ -0,0 +1,210 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidHexColor    = errors.New("invalid hex color")
+	ErrInvalidTemperature = errors.New("invalid color temperature")
+)
+
+// Luminance is the relative luminance of a color, using the Rec. 709
+// coefficients.
+func (t Tuple) Luminance() float64 {
+	return 0.2126*t.X + 0.7152*t.Y + 0.0722*t.Z
+}
+
+// Lerp is the linear interpolation between the Tuple (f = 0) and o (f = 1).
+func (t Tuple) Lerp(o Tuple, f float64) Tuple {
+	return Tuple{
+		X: t.X + (o.X-t.X)*f,
+		Y: t.Y + (o.Y-t.Y)*f,
+		Z: t.Z + (o.Z-t.Z)*f,
+		W: keepW(t.W+(o.W-t.W)*f, t.W),
+	}
+}
+
+// HSV returns the hue in degrees [0, 360), the saturation and the value of
+// a color.
+func (t Tuple) HSV() (float64, float64, float64) {
+	hi, lo := max(t.X, t.Y, t.Z), min(t.X, t.Y, t.Z)
+
+	s := 0.0
+	if hi != 0 {
+		s = (hi - lo) / hi
+	}
+
+	return hue(t, hi, lo), s, hi
+}
+
+// ColorFromHSV creates a new color from the hue in degrees, the saturation
+// and the value.
+func ColorFromHSV(h, s, v float64) Tuple {
+	c := v * s
+
+	return fromHue(h, c, v-c)
+}
+
+// HSL returns the hue in degrees [0, 360), the saturation and the lightness
+// of a color.
+func (t Tuple) HSL() (float64, float64, float64) {
+	hi, lo := max(t.X, t.Y, t.Z), min(t.X, t.Y, t.Z)
+	l := (hi + lo) / 2
+
+	s := 0.0
+	if d := 1 - math.Abs(2*l-1); d != 0 {
+		s = (hi - lo) / d
+	}
+
+	return hue(t, hi, lo), s, l
+}
+
+// ColorFromHSL creates a new color from the hue in degrees, the saturation
+// and the lightness.
+func ColorFromHSL(h, s, l float64) Tuple {
+	c := (1 - math.Abs(2*l-1)) * s
+
+	return fromHue(h, c, l-c/2)
+}
+
+// Hex returns the color as "#rrggbb", with each channel clamped to [0, 255].
+func (t Tuple) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", clamp(t.X, MaxColor), clamp(t.Y, MaxColor), clamp(t.Z, MaxColor))
+}
+
+// ParseHexColor creates a new color from "#rrggbb" or "#rgb", where the "#"
+// is optional.
+func ParseHexColor(s string) (Tuple, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return Tuple{}, fmt.Errorf("%w: %q", ErrInvalidHexColor, s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Tuple{}, fmt.Errorf("%w: %q", ErrInvalidHexColor, s)
+	}
+
+	return NewColor(
+		float64(v>>16&0xff)/MaxColor,
+		float64(v>>8&0xff)/MaxColor,
+		float64(v&0xff)/MaxColor,
+	), nil
+}
+
+// ColorFromTemperature creates a new color for a black body at the
+// temperature in Kelvin, between 1000 K (red) and 40000 K (blue), with 6600 K
+// being white. It's an approximation of the black body curve good enough for
+// light colors.
+func ColorFromTemperature(kelvin float64) (Tuple, error) {
+	if !(kelvin >= 1000 && kelvin <= 40000) {
+		return Tuple{}, fmt.Errorf("%w: %gK", ErrInvalidTemperature, kelvin)
+	}
+
+	t := kelvin / 100
+
+	var r, g, b float64
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+
+	channel := func(v float64) float64 {
+		return math.Min(math.Max(v, 0), MaxColor) / MaxColor
+	}
+
+	return NewColor(channel(r), channel(g), channel(b)), nil
+}
+
+// ParseColor creates a new color from a hex code, like "#ffcc00", or from a
+// temperature in Kelvin, like "3200K".
+func ParseColor(s string) (Tuple, error) {
+	s = strings.TrimSpace(s)
+
+	if k, ok := strings.CutSuffix(strings.ToUpper(s), "K"); ok {
+		kelvin, err := strconv.ParseFloat(strings.TrimSpace(k), 64)
+		if err != nil {
+			return Tuple{}, fmt.Errorf("%w: %q", ErrInvalidTemperature, s)
+		}
+
+		return ColorFromTemperature(kelvin)
+	}
+
+	return ParseHexColor(s)
+}
+
+// hue returns the hue in degrees [0, 360) of a color with the largest
+// channel hi and the smallest channel lo.
+func hue(t Tuple, hi, lo float64) float64 {
+	d := hi - lo
+	if d == 0 {
+		return 0
+	}
+
+	var h float64
+	switch hi {
+	case t.X:
+		h = math.Mod((t.Y-t.Z)/d, 6)
+	case t.Y:
+		h = (t.Z-t.X)/d + 2
+	default:
+		h = (t.X-t.Y)/d + 4
+	}
+
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+
+	return h
+}
+
+// fromHue creates a new color from the hue in degrees, the chroma c and the
+// value m added to every channel.
+func fromHue(h, c, m float64) Tuple {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	h /= 60
+
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+
+	var r, g, b float64
+	switch {
+	case h < 1:
+		r, g, b = c, x, 0
+	case h < 2:
+		r, g, b = x, c, 0
+	case h < 3:
+		r, g, b = 0, c, x
+	case h < 4:
+		r, g, b = 0, x, c
+	case h < 5:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return NewColor(r+m, g+m, b+m)
+}
@@ -0,0 +1,287 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestLuminance(t *testing.T) {
+	tests := []struct {
+		name  string
+		color feature.Tuple
+		want  float64
+	}{
+		{
+			name:  "black",
+			color: feature.ColorBlack,
+			want:  0,
+		},
+		{
+			name:  "white",
+			color: feature.ColorWhite,
+			want:  1,
+		},
+		{
+			name:  "green",
+			color: feature.ColorGreen,
+			want:  0.7152,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.color.Luminance(); math.Abs(got-test.want) > 0.00001 {
+				t.Errorf("%s wants %f and got %f", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestLerp(t *testing.T) {
+	got := feature.NewColor(0, 0.5, 1).Lerp(feature.NewColor(1, 0.5, 0), 0.25)
+	want := feature.NewColor(0.25, 0.5, 0.75)
+
+	if !want.IsEqual(got) {
+		t.Errorf("wants %+v and got %+v", want, got)
+	}
+}
+
+func TestHSVAndHSL(t *testing.T) {
+	tests := []struct {
+		name  string
+		color feature.Tuple
+		hsv   [3]float64
+		hsl   [3]float64
+	}{
+		{
+			name:  "red",
+			color: feature.ColorRed,
+			hsv:   [3]float64{0, 1, 1},
+			hsl:   [3]float64{0, 1, 0.5},
+		},
+		{
+			name:  "green",
+			color: feature.ColorGreen,
+			hsv:   [3]float64{120, 1, 1},
+			hsl:   [3]float64{120, 1, 0.5},
+		},
+		{
+			name:  "blue",
+			color: feature.ColorBlue,
+			hsv:   [3]float64{240, 1, 1},
+			hsl:   [3]float64{240, 1, 0.5},
+		},
+		{
+			name:  "magenta",
+			color: feature.NewColor(1, 0, 1),
+			hsv:   [3]float64{300, 1, 1},
+			hsl:   [3]float64{300, 1, 0.5},
+		},
+		{
+			name:  "orange",
+			color: feature.NewColor(1, 0.5, 0),
+			hsv:   [3]float64{30, 1, 1},
+			hsl:   [3]float64{30, 1, 0.5},
+		},
+		{
+			name:  "gray",
+			color: feature.NewColor(0.5, 0.5, 0.5),
+			hsv:   [3]float64{0, 0, 0.5},
+			hsl:   [3]float64{0, 0, 0.5},
+		},
+		{
+			name:  "dark teal",
+			color: feature.NewColor(0.1, 0.4, 0.4),
+			hsv:   [3]float64{180, 0.75, 0.4},
+			hsl:   [3]float64{180, 0.6, 0.25},
+		},
+	}
+
+	equal := func(a, b [3]float64) bool {
+		return math.Abs(a[0]-b[0]) < 0.00001 && math.Abs(a[1]-b[1]) < 0.00001 && math.Abs(a[2]-b[2]) < 0.00001
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h, s, v := test.color.HSV()
+			if got := [3]float64{h, s, v}; !equal(got, test.hsv) {
+				t.Errorf("%s wants HSV %v and got %v", test.name, test.hsv, got)
+			}
+			if got := feature.ColorFromHSV(test.hsv[0], test.hsv[1], test.hsv[2]); !test.color.IsEqual(got) {
+				t.Errorf("%s wants %+v from HSV and got %+v", test.name, test.color, got)
+			}
+
+			h, s, l := test.color.HSL()
+			if got := [3]float64{h, s, l}; !equal(got, test.hsl) {
+				t.Errorf("%s wants HSL %v and got %v", test.name, test.hsl, got)
+			}
+			if got := feature.ColorFromHSL(test.hsl[0], test.hsl[1], test.hsl[2]); !test.color.IsEqual(got) {
+				t.Errorf("%s wants %+v from HSL and got %+v", test.name, test.color, got)
+			}
+		})
+	}
+
+	if got := feature.ColorFromHSV(-240, 1, 1); !got.IsEqual(feature.ColorGreen) {
+		t.Errorf("negative hue wants %+v and got %+v", feature.ColorGreen, got)
+	}
+}
+
+func TestHex(t *testing.T) {
+	tests := []struct {
+		name string
+		hex  string
+		want feature.Tuple
+		back string
+		err  error
+	}{
+		{
+			name: "long",
+			hex:  "#ff8000",
+			want: feature.NewColor(1, 128.0/255, 0),
+			back: "#ff8000",
+		},
+		{
+			name: "short",
+			hex:  "#f0c",
+			want: feature.NewColor(1, 0, 0.8),
+			back: "#ff00cc",
+		},
+		{
+			name: "without hash",
+			hex:  "FFFFFF",
+			want: feature.ColorWhite,
+			back: "#ffffff",
+		},
+		{
+			name: "invalid length",
+			hex:  "#ff80",
+			err:  feature.ErrInvalidHexColor,
+		},
+		{
+			name: "invalid digit",
+			hex:  "#gg8000",
+			err:  feature.ErrInvalidHexColor,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := feature.ParseHexColor(test.hex)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if !test.want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, got)
+			}
+			if got.Hex() != test.back {
+				t.Errorf("%s wants %q and got %q", test.name, test.back, got.Hex())
+			}
+		})
+	}
+
+	if got := feature.NewColor(1.5, -1, 0.5).Hex(); got != "#ff0080" {
+		t.Errorf("clamped wants %q and got %q", "#ff0080", got)
+	}
+}
+
+func TestColorFromTemperature(t *testing.T) {
+	candle, err := feature.ColorFromTemperature(1900)
+	if err != nil {
+		t.Fatalf("error creating a color: %v", err)
+	}
+	tungsten, err := feature.ColorFromTemperature(3200)
+	if err != nil {
+		t.Fatalf("error creating a color: %v", err)
+	}
+	daylight, err := feature.ColorFromTemperature(6600)
+	if err != nil {
+		t.Fatalf("error creating a color: %v", err)
+	}
+	sky, err := feature.ColorFromTemperature(15000)
+	if err != nil {
+		t.Fatalf("error creating a color: %v", err)
+	}
+
+	if candle.Z != 0 || candle.X != 1 {
+		t.Errorf("expected a candle to be red without blue, got %+v", candle)
+	}
+	if !(tungsten.X == 1 && tungsten.X > tungsten.Y && tungsten.Y > tungsten.Z) {
+		t.Errorf("expected tungsten to be warm, got %+v", tungsten)
+	}
+	if !daylight.IsEqualTol(feature.ColorWhite, feature.Tolerance{Abs: 0.02}) {
+		t.Errorf("expected 6600K to be white, got %+v", daylight)
+	}
+	if !(sky.Z == 1 && sky.Z > sky.X) {
+		t.Errorf("expected a clear sky to be blue, got %+v", sky)
+	}
+
+	for _, k := range []float64{999, 40001, math.NaN()} {
+		if _, err := feature.ColorFromTemperature(k); !errors.Is(err, feature.ErrInvalidTemperature) {
+			t.Errorf("%gK: got error %v, expected error %v", k, err, feature.ErrInvalidTemperature)
+		}
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tungsten, err := feature.ColorFromTemperature(3200)
+	if err != nil {
+		t.Fatalf("error creating a color: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		color string
+		want  feature.Tuple
+		err   error
+	}{
+		{
+			name:  "temperature",
+			color: "3200K",
+			want:  tungsten,
+		},
+		{
+			name:  "temperature lower case",
+			color: " 3200 k ",
+			want:  tungsten,
+		},
+		{
+			name:  "hex",
+			color: "#00ff00",
+			want:  feature.ColorGreen,
+		},
+		{
+			name:  "NaN temperature",
+			color: "NaNK",
+			err:   feature.ErrInvalidTemperature,
+		},
+		{
+			name:  "invalid temperature",
+			color: "hotK",
+			err:   feature.ErrInvalidTemperature,
+		},
+		{
+			name:  "invalid hex",
+			color: "green",
+			err:   feature.ErrInvalidHexColor,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := feature.ParseColor(test.color)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if !test.want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package feature
+
+import (
+	"errors"
+	"math"
+)
+
+// ssimWindow is the size of the square windows used to compute the SSIM.
+const ssimWindow = 8
+
+var ErrCanvasSizeMismatch = errors.New("canvas sizes don't match")
+
+// Comparison is the difference between two canvases.
+type Comparison struct {
+	// MaxError is the largest absolute difference of any color channel.
+	MaxError float64
+	// MaxAlphaError is the largest absolute difference of the alpha.
+	MaxAlphaError float64
+	// MeanError is the mean absolute difference of all color channels.
+	MeanError float64
+	// PSNR is the peak signal-to-noise ratio in decibels, with 1.0 as the
+	// peak value. It's +Inf when both canvases are equal.
+	PSNR float64
+	// SSIM is the mean structural similarity of the luminance, where 1.0
+	// means equal canvases.
+	SSIM float64
+	// Diff is a heat map of the largest channel or alpha difference of each
+	// pixel, going from black (no difference) to red, yellow and white (the
+	// largest of MaxError and MaxAlphaError).
+	Diff *Canvas
+}
+
+// Compare returns the difference between the colors and the alpha of the
+// canvases a and b. Only the colors count for MeanError, PSNR and SSIM.
+// It returns an error if their sizes don't match.
+func Compare(a, b *Canvas) (Comparison, error) {
+	var cmp Comparison
+
+	if a.width != b.width || a.height != b.height {
+		return cmp, ErrCanvasSizeMismatch
+	}
+
+	errs := make([]float64, len(a.pixels))
+	sum, squares := 0.0, 0.0
+	for i := range a.pixels {
+		p, q := a.pixels[i], b.pixels[i]
+		for _, d := range [3]float64{p.X - q.X, p.Y - q.Y, p.Z - q.Z} {
+			d = math.Abs(d)
+			sum += d
+			squares += d * d
+			errs[i] = math.Max(errs[i], d)
+		}
+		cmp.MaxError = math.Max(cmp.MaxError, errs[i])
+
+		da := math.Abs(a.alpha[i] - b.alpha[i])
+		cmp.MaxAlphaError = math.Max(cmp.MaxAlphaError, da)
+		errs[i] = math.Max(errs[i], da)
+	}
+
+	samples := float64(3 * len(a.pixels))
+	cmp.MeanError = sum / samples
+	cmp.PSNR = math.Inf(1)
+	if mse := squares / samples; mse > 0 {
+		cmp.PSNR = 10 * math.Log10(1/mse)
+	}
+	cmp.SSIM = ssim(a, b)
+
+	cmp.Diff = blankCanvas(a.width, a.height)
+	if peak := math.Max(cmp.MaxError, cmp.MaxAlphaError); peak > 0 {
+		for i, e := range errs {
+			cmp.Diff.pixels[i] = heat(e / peak)
+		}
+	}
+
+	return cmp, nil
+}
+
+// ssim is the mean structural similarity of the luminance of a and b,
+// computed over square windows of ssimWindow pixels.
+func ssim(a, b *Canvas) float64 {
+	const (
+		c1 = 0.01 * 0.01
+		c2 = 0.03 * 0.03
+	)
+
+	total, windows := 0.0, 0
+	for y0 := 0; y0 < a.height; y0 += ssimWindow {
+		for x0 := 0; x0 < a.width; x0 += ssimWindow {
+			var ma, mb, va, vb, cov, n float64
+			for y := y0; y < min(y0+ssimWindow, a.height); y++ {
+				for x := x0; x < min(x0+ssimWindow, a.width); x++ {
+					ma += a.pixels[y*a.width+x].Luminance()
+					mb += b.pixels[y*a.width+x].Luminance()
+					n++
+				}
+			}
+			ma /= n
+			mb /= n
+
+			for y := y0; y < min(y0+ssimWindow, a.height); y++ {
+				for x := x0; x < min(x0+ssimWindow, a.width); x++ {
+					da := a.pixels[y*a.width+x].Luminance() - ma
+					db := b.pixels[y*a.width+x].Luminance() - mb
+					va += da * da
+					vb += db * db
+					cov += da * db
+				}
+			}
+			va /= n
+			vb /= n
+			cov /= n
+
+			total += ((2*ma*mb + c1) * (2*cov + c2)) / ((ma*ma + mb*mb + c1) * (va + vb + c2))
+			windows++
+		}
+	}
+
+	return total / float64(windows)
+}
+
+// heat maps a value in [0, 1] to a color going from black to red, yellow
+// and white.
+func heat(v float64) Tuple {
+	v = math.Min(math.Max(v, 0), 1) * 3
+
+	return NewColor(
+		math.Min(v, 1),
+		math.Min(math.Max(v-1, 0), 1),
+		math.Min(math.Max(v-2, 0), 1),
+	)
+}
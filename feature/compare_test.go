@@ -0,0 +1,130 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	gray := feature.NewColor(0.5, 0.5, 0.5)
+	light := feature.NewColor(0.6, 0.5, 0.5)
+
+	tests := []struct {
+		name  string
+		a     [][]feature.Tuple
+		b     [][]feature.Tuple
+		max   float64
+		mean  float64
+		psnr  float64
+		equal bool
+		diff  [][]feature.Tuple
+		err   error
+	}{
+		{
+			name:  "equal",
+			a:     [][]feature.Tuple{{gray, red}, {green, blue}},
+			b:     [][]feature.Tuple{{gray, red}, {green, blue}},
+			max:   0,
+			mean:  0,
+			psnr:  math.Inf(1),
+			equal: true,
+			diff:  [][]feature.Tuple{{black, black}, {black, black}},
+		},
+		{
+			name: "one channel",
+			a:    [][]feature.Tuple{{gray, gray}},
+			b:    [][]feature.Tuple{{light, gray}},
+			max:  0.1,
+			mean: 0.1 / 6,
+			psnr: 10 * math.Log10(6/0.01),
+			diff: [][]feature.Tuple{{white, black}},
+		},
+		{
+			name: "size mismatch",
+			a:    [][]feature.Tuple{{gray, gray}},
+			b:    [][]feature.Tuple{{gray}},
+			err:  feature.ErrCanvasSizeMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := feature.Compare(newCanvasFrom(t, test.a), newCanvasFrom(t, test.b))
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if math.Abs(got.MaxError-test.max) > 0.00001 {
+				t.Errorf("%q: wants max error %f and got %f", test.name, test.max, got.MaxError)
+			}
+			if math.Abs(got.MeanError-test.mean) > 0.00001 {
+				t.Errorf("%q: wants mean error %f and got %f", test.name, test.mean, got.MeanError)
+			}
+			if got.PSNR != test.psnr && math.Abs(got.PSNR-test.psnr) > 0.00001 {
+				t.Errorf("%q: wants PSNR %f and got %f", test.name, test.psnr, got.PSNR)
+			}
+			if test.equal && got.SSIM != 1 {
+				t.Errorf("%q: wants SSIM 1 and got %f", test.name, got.SSIM)
+			}
+			if !test.equal && got.SSIM >= 1 {
+				t.Errorf("%q: wants SSIM below 1 and got %f", test.name, got.SSIM)
+			}
+			assertCanvas(t, test.name, got.Diff, test.diff)
+		})
+	}
+}
+
+func TestCompareAlpha(t *testing.T) {
+	a := newCanvasFrom(t, [][]feature.Tuple{{red, red}})
+	b := newCanvasFrom(t, [][]feature.Tuple{{red, red}})
+	if err := b.WritePixelAlpha(1, 0, red, 0.25); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	got, err := feature.Compare(a, b)
+	if err != nil {
+		t.Fatalf("error comparing: %v", err)
+	}
+
+	if got.MaxError != 0 || got.MaxAlphaError != 0.75 {
+		t.Errorf("wants max error 0 and max alpha error 0.75 but got %g and %g", got.MaxError, got.MaxAlphaError)
+	}
+	assertCanvas(t, "alpha", got.Diff, [][]feature.Tuple{{black, white}})
+}
+
+func TestCompareSSIM(t *testing.T) {
+	gray := feature.NewColor(0.5, 0.5, 0.5)
+	noise := feature.NewColor(0.55, 0.55, 0.55)
+
+	reference := [][]feature.Tuple{
+		{black, white, black, white},
+		{white, black, white, black},
+	}
+	similar := [][]feature.Tuple{
+		{black, white, black, white},
+		{white, black, white, noise},
+	}
+	flat := [][]feature.Tuple{
+		{gray, gray, gray, gray},
+		{gray, gray, gray, gray},
+	}
+
+	a, err := feature.Compare(newCanvasFrom(t, reference), newCanvasFrom(t, similar))
+	if err != nil {
+		t.Fatalf("error comparing: %v", err)
+	}
+	b, err := feature.Compare(newCanvasFrom(t, reference), newCanvasFrom(t, flat))
+	if err != nil {
+		t.Fatalf("error comparing: %v", err)
+	}
+
+	if a.SSIM <= b.SSIM {
+		t.Errorf("expected a similar structure to score higher than a flat canvas, got %f and %f", a.SSIM, b.SSIM)
+	}
+}
@@ -0,0 +1,130 @@
+package feature
+
+import (
+	"errors"
+	"math"
+)
+
+// CompositeOp is a Porter-Duff operator that combines a source pixel with a
+// destination pixel, based on the area covered by each one.
+type CompositeOp int
+
+const (
+	// CompositeClear leaves the destination transparent.
+	CompositeClear CompositeOp = iota
+	// CompositeSrc keeps only the source.
+	CompositeSrc
+	// CompositeDst keeps only the destination.
+	CompositeDst
+	// CompositeOver draws the source over the destination.
+	CompositeOver
+	// CompositeDstOver draws the destination over the source.
+	CompositeDstOver
+	// CompositeIn keeps the source where the destination is.
+	CompositeIn
+	// CompositeDstIn keeps the destination where the source is.
+	CompositeDstIn
+	// CompositeOut keeps the source where the destination isn't.
+	CompositeOut
+	// CompositeDstOut keeps the destination where the source isn't.
+	CompositeDstOut
+	// CompositeAtop draws the source over the destination, only where the
+	// destination is.
+	CompositeAtop
+	// CompositeDstAtop draws the destination over the source, only where the
+	// source is.
+	CompositeDstAtop
+	// CompositeXor keeps the source and the destination where they don't
+	// overlap.
+	CompositeXor
+	// CompositePlus adds the source and the destination.
+	CompositePlus
+)
+
+var ErrInvalidCompositeOp = errors.New("invalid composite operator")
+
+// Composite combines src with the Canvas using the operator op, with the src
+// top left corner at the position x and y. The parts of src out of the
+// Canvas bounds are ignored.
+func (c *Canvas) Composite(src *Canvas, x, y int, op CompositeOp) error {
+	if op < CompositeClear || op > CompositePlus {
+		return ErrInvalidCompositeOp
+	}
+
+	c.composite(src, x, y, op, 1.0)
+
+	return nil
+}
+
+// composite combines src with the Canvas using the operator op, scaling the
+// src alpha by opacity.
+func (c *Canvas) composite(src *Canvas, x, y int, op CompositeOp, opacity float64) {
+	for sy := max(0, -y); sy < src.height && y+sy < c.height; sy++ {
+		for sx := max(0, -x); sx < src.width && x+sx < c.width; sx++ {
+			s := sy*src.width + sx
+			d := (y+sy)*c.width + x + sx
+			c.pixels[d], c.alpha[d] = compositePixel(src.pixels[s], src.alpha[s]*opacity, c.pixels[d], c.alpha[d], op)
+		}
+	}
+}
+
+// compositePixel combines the source color cs with alpha as over the
+// destination color cd with alpha ad.
+func compositePixel(cs Tuple, as float64, cd Tuple, ad float64, op CompositeOp) (Tuple, float64) {
+	fs, fd := compositeFactors(as, ad, op)
+
+	p := Tuple{
+		X: cs.X*as*fs + cd.X*ad*fd,
+		Y: cs.Y*as*fs + cd.Y*ad*fd,
+		Z: cs.Z*as*fs + cd.Z*ad*fd,
+		W: as*fs + ad*fd,
+	}
+
+	return unpremultiply(p)
+}
+
+// compositeFactors returns the fraction of the source and of the
+// destination kept by op.
+func compositeFactors(as, ad float64, op CompositeOp) (float64, float64) {
+	switch op {
+	case CompositeSrc:
+		return 1, 0
+	case CompositeDst:
+		return 0, 1
+	case CompositeOver:
+		return 1, 1 - as
+	case CompositeDstOver:
+		return 1 - ad, 1
+	case CompositeIn:
+		return ad, 0
+	case CompositeDstIn:
+		return 0, as
+	case CompositeOut:
+		return 1 - ad, 0
+	case CompositeDstOut:
+		return 0, 1 - as
+	case CompositeAtop:
+		return ad, 1 - as
+	case CompositeDstAtop:
+		return 1 - ad, as
+	case CompositeXor:
+		return 1 - ad, 1 - as
+	case CompositePlus:
+		return 1, 1
+	}
+
+	return 0, 0
+}
+
+// unpremultiply splits a color premultiplied by the alpha stored in W into
+// a color and its alpha, clamped to [0, 1]. The color is divided by the
+// clamped alpha, so an alpha above 1 (like opaque plus opaque) keeps the
+// summed color instead of averaging it.
+func unpremultiply(p Tuple) (Tuple, float64) {
+	if !(p.W > 0) {
+		return ColorBlack, 0
+	}
+	a := math.Min(p.W, 1)
+
+	return NewColor(p.X/a, p.Y/a, p.Z/a), a
+}
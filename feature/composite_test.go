@@ -0,0 +1,226 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestComposite(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       feature.Tuple
+		srcAlpha  float64
+		dst       feature.Tuple
+		dstAlpha  float64
+		op        feature.CompositeOp
+		want      feature.Tuple
+		wantAlpha float64
+		err       error
+	}{
+		{
+			name:      "clear",
+			src:       red,
+			srcAlpha:  1,
+			dst:       blue,
+			dstAlpha:  1,
+			op:        feature.CompositeClear,
+			want:      black,
+			wantAlpha: 0,
+		},
+		{
+			name:      "src",
+			src:       red,
+			srcAlpha:  0.5,
+			dst:       blue,
+			dstAlpha:  1,
+			op:        feature.CompositeSrc,
+			want:      red,
+			wantAlpha: 0.5,
+		},
+		{
+			name:      "over opaque",
+			src:       red,
+			srcAlpha:  0.5,
+			dst:       blue,
+			dstAlpha:  1,
+			op:        feature.CompositeOver,
+			want:      feature.NewColor(0.5, 0, 0.5),
+			wantAlpha: 1,
+		},
+		{
+			name:      "over transparent",
+			src:       red,
+			srcAlpha:  0.5,
+			dst:       blue,
+			dstAlpha:  0,
+			op:        feature.CompositeOver,
+			want:      red,
+			wantAlpha: 0.5,
+		},
+		{
+			name:      "dst over",
+			src:       red,
+			srcAlpha:  1,
+			dst:       blue,
+			dstAlpha:  0.5,
+			op:        feature.CompositeDstOver,
+			want:      feature.NewColor(0.5, 0, 0.5),
+			wantAlpha: 1,
+		},
+		{
+			name:      "in",
+			src:       red,
+			srcAlpha:  1,
+			dst:       blue,
+			dstAlpha:  0.5,
+			op:        feature.CompositeIn,
+			want:      red,
+			wantAlpha: 0.5,
+		},
+		{
+			name:      "out",
+			src:       red,
+			srcAlpha:  1,
+			dst:       blue,
+			dstAlpha:  0.25,
+			op:        feature.CompositeOut,
+			want:      red,
+			wantAlpha: 0.75,
+		},
+		{
+			name:      "atop",
+			src:       red,
+			srcAlpha:  0.5,
+			dst:       blue,
+			dstAlpha:  0.5,
+			op:        feature.CompositeAtop,
+			want:      feature.NewColor(0.5, 0, 0.5),
+			wantAlpha: 0.5,
+		},
+		{
+			name:      "xor",
+			src:       red,
+			srcAlpha:  1,
+			dst:       blue,
+			dstAlpha:  1,
+			op:        feature.CompositeXor,
+			want:      black,
+			wantAlpha: 0,
+		},
+		{
+			name:      "plus",
+			src:       red,
+			srcAlpha:  0.5,
+			dst:       blue,
+			dstAlpha:  0.25,
+			op:        feature.CompositePlus,
+			want:      feature.NewColor(0.5/0.75, 0, 0.25/0.75),
+			wantAlpha: 0.75,
+		},
+		{
+			name:      "plus opaque",
+			src:       red,
+			srcAlpha:  1,
+			dst:       feature.ColorGreen,
+			dstAlpha:  1,
+			op:        feature.CompositePlus,
+			want:      feature.NewColor(1, 1, 0),
+			wantAlpha: 1,
+		},
+		{
+			name:      "invalid",
+			src:       red,
+			srcAlpha:  1,
+			dst:       blue,
+			dstAlpha:  1,
+			op:        feature.CompositeOp(99),
+			want:      blue,
+			wantAlpha: 1,
+			err:       feature.ErrInvalidCompositeOp,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, err := feature.NewCanvas(1, 1)
+			if err != nil {
+				t.Fatalf("error creating a new canvas: %v", err)
+			}
+			dst, err := feature.NewCanvas(1, 1)
+			if err != nil {
+				t.Fatalf("error creating a new canvas: %v", err)
+			}
+			if err := src.WritePixelAlpha(0, 0, test.src, test.srcAlpha); err != nil {
+				t.Fatalf("error writing pixel: %v", err)
+			}
+			if err := dst.WritePixelAlpha(0, 0, test.dst, test.dstAlpha); err != nil {
+				t.Fatalf("error writing pixel: %v", err)
+			}
+
+			err = dst.Composite(src, 0, 0, test.op)
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+
+			got, alpha, err := dst.PixelAlpha(0, 0)
+			if err != nil {
+				t.Fatalf("error reading pixel: %v", err)
+			}
+			if !got.IsEqual(test.want) {
+				t.Errorf("%q: wants %+v and got %+v", test.name, test.want, got)
+			}
+			if math.Abs(alpha-test.wantAlpha) > 0.00001 {
+				t.Errorf("%q: wants alpha %f and got %f", test.name, test.wantAlpha, alpha)
+			}
+		})
+	}
+}
+
+func TestBlitAlpha(t *testing.T) {
+	dst := newCanvasFrom(t, [][]feature.Tuple{{blue, blue}})
+	src, err := feature.NewCanvas(2, 1)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	// A transparent pixel in src keeps the destination.
+	src.Clear()
+	if err := src.WritePixel(1, 0, red); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	if err := dst.Blit(src, 0, 0, 1); err != nil {
+		t.Fatalf("error blitting: %v", err)
+	}
+	assertCanvas(t, "blit", dst, [][]feature.Tuple{{blue, red}})
+}
+
+func TestResizeTransparent(t *testing.T) {
+	canvas, err := feature.NewCanvas(2, 1)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	// The transparent white pixel must not bleed into the red one.
+	if err := canvas.WritePixelAlpha(0, 0, white, 0); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	if err := canvas.WritePixel(1, 0, red); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	got, err := canvas.Resize(1, 1, feature.FilterBilinear)
+	if err != nil {
+		t.Fatalf("error resizing: %v", err)
+	}
+
+	p, alpha, err := got.PixelAlpha(0, 0)
+	if err != nil {
+		t.Fatalf("error reading pixel: %v", err)
+	}
+	if !p.IsEqual(red) || math.Abs(alpha-0.5) > 0.00001 {
+		t.Errorf("wants %+v with alpha 0.5 and got %+v with alpha %f", red, p, alpha)
+	}
+}
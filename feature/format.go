@@ -0,0 +1,98 @@
+package feature
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter. The verbs %v and %s write the Tuple as
+// "point(1, 2, 3)", "vector(1, 2, 3)", "color(1, 0.5, 0)" or
+// "tuple(1, 2, 3, 4)", %+v adds the field names, like
+// "point(x: 1, y: 2, z: 3)", and %#v writes it in Go syntax.
+// The verbs %e, %f and %g, the precision and the width are applied to each
+// value, so %.3v and %.2f round the values.
+func (t Tuple) Format(f fmt.State, verb rune) {
+	kind, values := t.kind()
+	names := []string{"x", "y", "z", "w"}[:len(values)]
+	if kind == kindColor {
+		names = []string{"r", "g", "b"}
+	}
+
+	formatValues(f, verb, kind, "feature.Tuple", names, []string{"X", "Y", "Z", "W"}, values, []float64{t.X, t.Y, t.Z, t.W})
+}
+
+// Format implements fmt.Formatter, like Tuple.Format, writing the Point as
+// "point(1, 2, 3)".
+func (p Point) Format(f fmt.State, verb rune) {
+	values := []float64{p.X, p.Y, p.Z}
+
+	formatValues(f, verb, kindPoint, "feature.Point", []string{"x", "y", "z"}, []string{"X", "Y", "Z"}, values, values)
+}
+
+// Format implements fmt.Formatter, like Tuple.Format, writing the Vector as
+// "vector(1, 2, 3)".
+func (v Vector) Format(f fmt.State, verb rune) {
+	values := []float64{v.X, v.Y, v.Z}
+
+	formatValues(f, verb, kindVector, "feature.Vector", []string{"x", "y", "z"}, []string{"X", "Y", "Z"}, values, values)
+}
+
+// Format implements fmt.Formatter, like Tuple.Format, writing the Color as
+// "color(1, 0.5, 0)".
+func (c Color) Format(f fmt.State, verb rune) {
+	values := []float64{c.R, c.G, c.B}
+
+	formatValues(f, verb, kindColor, "feature.Color", []string{"r", "g", "b"}, []string{"R", "G", "B"}, values, values)
+}
+
+// formatValues writes the values of a tuple kind following the verb and
+// flags of f. The fields and goValues are the struct fields written by %#v.
+func formatValues(f fmt.State, verb rune, kind, goType string, names, fields []string, values, goValues []float64) {
+	switch verb {
+	case 'v', 's', 'e', 'E', 'f', 'F', 'g', 'G':
+	default:
+		fmt.Fprintf(f, "%%!%c(%s=%s)", verb, goType, formatTuple(kind, values...))
+		return
+	}
+
+	if verb == 'v' && f.Flag('#') {
+		s := make([]string, len(goValues))
+		for i, v := range goValues {
+			s[i] = fmt.Sprintf("%s:%s", fields[i], strconv.FormatFloat(v, 'g', -1, 64))
+		}
+		fmt.Fprintf(f, "%s{%s}", goType, strings.Join(s, ", "))
+		return
+	}
+
+	// Each value is written with the same verb and flags, with %v and %s
+	// behaving like %g.
+	format := "%"
+	for _, flag := range "+- 0" {
+		if f.Flag(int(flag)) && !(flag == '+' && (verb == 'v' || verb == 's')) {
+			format += string(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		format += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(p)
+	}
+	switch verb {
+	case 'v', 's':
+		format += "v"
+	default:
+		format += string(verb)
+	}
+
+	s := make([]string, len(names))
+	for i := range names {
+		s[i] = fmt.Sprintf(format, values[i])
+		if (verb == 'v' || verb == 's') && f.Flag('+') {
+			s[i] = names[i] + ": " + s[i]
+		}
+	}
+
+	fmt.Fprintf(f, "%s(%s)", kind, strings.Join(s, ", "))
+}
@@ -0,0 +1,138 @@
+package feature_test
+
+import (
+	"fmt"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		value  any
+		want   string
+	}{
+		{
+			name:   "point",
+			format: "%v",
+			value:  feature.NewPoint(1, 2, 3),
+			want:   "point(1, 2, 3)",
+		},
+		{
+			name:   "vector",
+			format: "%s",
+			value:  feature.NewVector(0.5, -1, 0),
+			want:   "vector(0.5, -1, 0)",
+		},
+		{
+			name:   "tuple",
+			format: "%v",
+			value:  feature.Tuple{X: 1, Y: 2, Z: 3, W: 0.5},
+			want:   "tuple(1, 2, 3, 0.5)",
+		},
+		{
+			name:   "field names",
+			format: "%+v",
+			value:  feature.NewPoint(1, 2, 3),
+			want:   "point(x: 1, y: 2, z: 3)",
+		},
+		{
+			name:   "precision",
+			format: "%.3v",
+			value:  feature.NewVector(0.123456, 1.0/3, 12.5),
+			want:   "vector(0.123, 0.333, 12.5)",
+		},
+		{
+			name:   "fixed",
+			format: "%.2f",
+			value:  feature.NewPoint(1, 2.345, -3),
+			want:   "point(1.00, 2.35, -3.00)",
+		},
+		{
+			name:   "width",
+			format: "%6.1f",
+			value:  feature.NewPoint(1, 22, 333),
+			want:   "point(   1.0,   22.0,  333.0)",
+		},
+		{
+			name:   "plus with a number verb",
+			format: "%+.1f",
+			value:  feature.NewVector(1, -1, 0),
+			want:   "vector(+1.0, -1.0, +0.0)",
+		},
+		{
+			name:   "go syntax",
+			format: "%#v",
+			value:  feature.NewPoint(1, 2.5, 3),
+			want:   "feature.Tuple{X:1, Y:2.5, Z:3, W:1}",
+		},
+		{
+			name:   "bad verb",
+			format: "%d",
+			value:  feature.NewPoint(1, 2, 3),
+			want:   "%!d(feature.Tuple=point(1, 2, 3))",
+		},
+		{
+			name:   "typed point",
+			format: "%v",
+			value:  feature.Pt(1, 2, 3),
+			want:   "point(1, 2, 3)",
+		},
+		{
+			name:   "typed vector",
+			format: "%+v",
+			value:  feature.Vec(1, 2, 3),
+			want:   "vector(x: 1, y: 2, z: 3)",
+		},
+		{
+			name:   "color",
+			format: "%+v",
+			value:  feature.ColorRed,
+			want:   "color(r: 1, g: 0, b: 0)",
+		},
+		{
+			name:   "computed color",
+			format: "%v",
+			value:  feature.ColorRed.HadamardProduct(feature.NewColor(0.5, 1, 1)).Mul(2),
+			want:   "color(1, 0, 0)",
+		},
+		{
+			name:   "typed color",
+			format: "%v",
+			value:  feature.RGB(1, 0.5, 0),
+			want:   "color(1, 0.5, 0)",
+		},
+		{
+			name:   "typed color field names",
+			format: "%+.2v",
+			value:  feature.RGB(1, 0.555, 0),
+			want:   "color(r: 1, g: 0.56, b: 0)",
+		},
+		{
+			name:   "typed go syntax",
+			format: "%#v",
+			value:  feature.RGB(1, 0.5, 0),
+			want:   "feature.Color{R:1, G:0.5, B:0}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := fmt.Sprintf(test.format, test.value)
+
+			if got != test.want {
+				t.Errorf("%q: wants %q and got %q", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	got := feature.NewPoint(1, 2, 3).String()
+	want := "point(1, 2, 3)"
+
+	if got != want {
+		t.Errorf("wants %q and got %q", want, got)
+	}
+}
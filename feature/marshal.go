@@ -0,0 +1,396 @@
+package feature
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The kinds of Tuple in the text and JSON formats.
+const (
+	kindPoint  = "point"
+	kindVector = "vector"
+	kindColor  = "color"
+	kindTuple  = "tuple"
+)
+
+var (
+	ErrInvalidTupleText  = errors.New("invalid tuple text")
+	ErrInvalidTupleKind  = errors.New("invalid tuple kind")
+	ErrInvalidCanvasData = errors.New("invalid canvas data")
+)
+
+// tupleJSON is the JSON form of the Tuple kinds, where only the fields of
+// the kind are set.
+type tupleJSON struct {
+	Type string   `json:"type"`
+	X    *float64 `json:"x,omitempty"`
+	Y    *float64 `json:"y,omitempty"`
+	Z    *float64 `json:"z,omitempty"`
+	W    *float64 `json:"w,omitempty"`
+	R    *float64 `json:"r,omitempty"`
+	G    *float64 `json:"g,omitempty"`
+	B    *float64 `json:"b,omitempty"`
+}
+
+// MarshalText returns the Tuple as "point(x, y, z)", "vector(x, y, z)",
+// "color(r, g, b)" or, when it's none of them, "tuple(x, y, z, w)".
+func (t Tuple) MarshalText() ([]byte, error) {
+	kind, values := t.kind()
+
+	return []byte(formatTuple(kind, values...)), nil
+}
+
+// UnmarshalText reads a Tuple in the MarshalText format.
+func (t *Tuple) UnmarshalText(text []byte) error {
+	kind, values, err := parseTuple(string(text))
+	if err != nil {
+		return err
+	}
+
+	return t.set(kind, values)
+}
+
+// MarshalJSON returns the Tuple as a JSON object with its kind in "type",
+// like {"type":"point","x":1,"y":2,"z":3}.
+func (t Tuple) MarshalJSON() ([]byte, error) {
+	kind, values := t.kind()
+	j := tupleJSON{Type: kind, X: &values[0], Y: &values[1], Z: &values[2]}
+	switch kind {
+	case kindColor:
+		j = tupleJSON{Type: kind, R: &values[0], G: &values[1], B: &values[2]}
+	case kindTuple:
+		j.W = &values[3]
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON reads a Tuple in the MarshalJSON format or a JSON string in
+// the MarshalText format.
+func (t *Tuple) UnmarshalJSON(data []byte) error {
+	kind, values, err := unmarshalTupleJSON(data)
+	if err != nil {
+		return err
+	}
+
+	return t.set(kind, values)
+}
+
+// MarshalText returns the Point as "point(x, y, z)".
+func (p Point) MarshalText() ([]byte, error) {
+	return []byte(formatTuple(kindPoint, p.X, p.Y, p.Z)), nil
+}
+
+// UnmarshalText reads a Point in the MarshalText format.
+func (p *Point) UnmarshalText(text []byte) error {
+	values, err := parseKind(string(text), kindPoint)
+	if err != nil {
+		return err
+	}
+
+	*p = Pt(values[0], values[1], values[2])
+
+	return nil
+}
+
+// MarshalJSON returns the Point as {"type":"point","x":1,"y":2,"z":3}.
+func (p Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tupleJSON{Type: kindPoint, X: &p.X, Y: &p.Y, Z: &p.Z})
+}
+
+// UnmarshalJSON reads a Point in the MarshalJSON or MarshalText format.
+func (p *Point) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalKindJSON(data, kindPoint)
+	if err != nil {
+		return err
+	}
+
+	*p = Pt(values[0], values[1], values[2])
+
+	return nil
+}
+
+// MarshalText returns the Vector as "vector(x, y, z)".
+func (v Vector) MarshalText() ([]byte, error) {
+	return []byte(formatTuple(kindVector, v.X, v.Y, v.Z)), nil
+}
+
+// UnmarshalText reads a Vector in the MarshalText format.
+func (v *Vector) UnmarshalText(text []byte) error {
+	values, err := parseKind(string(text), kindVector)
+	if err != nil {
+		return err
+	}
+
+	*v = Vec(values[0], values[1], values[2])
+
+	return nil
+}
+
+// MarshalJSON returns the Vector as {"type":"vector","x":1,"y":2,"z":3}.
+func (v Vector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tupleJSON{Type: kindVector, X: &v.X, Y: &v.Y, Z: &v.Z})
+}
+
+// UnmarshalJSON reads a Vector in the MarshalJSON or MarshalText format.
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalKindJSON(data, kindVector)
+	if err != nil {
+		return err
+	}
+
+	*v = Vec(values[0], values[1], values[2])
+
+	return nil
+}
+
+// MarshalText returns the Color as "color(r, g, b)".
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(formatTuple(kindColor, c.R, c.G, c.B)), nil
+}
+
+// UnmarshalText reads a Color in the MarshalText format.
+func (c *Color) UnmarshalText(text []byte) error {
+	values, err := parseKind(string(text), kindColor)
+	if err != nil {
+		return err
+	}
+
+	*c = RGB(values[0], values[1], values[2])
+
+	return nil
+}
+
+// MarshalJSON returns the Color as {"type":"color","r":1,"g":0.5,"b":0}.
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tupleJSON{Type: kindColor, R: &c.R, G: &c.G, B: &c.B})
+}
+
+// UnmarshalJSON reads a Color in the MarshalJSON or MarshalText format.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalKindJSON(data, kindColor)
+	if err != nil {
+		return err
+	}
+
+	*c = RGB(values[0], values[1], values[2])
+
+	return nil
+}
+
+// canvasJSON is the compact JSON form of a Canvas, with the colors as a flat
+// list of red, green and blue values in row-major order. The alpha is only
+// present when any pixel isn't opaque.
+type canvasJSON struct {
+	Width  int       `json:"width"`
+	Height int       `json:"height"`
+	Pixels []float64 `json:"pixels"`
+	Alpha  []float64 `json:"alpha,omitempty"`
+}
+
+// MarshalJSON returns the Canvas in a compact JSON form, like
+// {"width":2,"height":1,"pixels":[1,0,0,0,0,1]}.
+func (c *Canvas) MarshalJSON() ([]byte, error) {
+	j := canvasJSON{
+		Width:  c.width,
+		Height: c.height,
+		Pixels: make([]float64, 0, 3*len(c.pixels)),
+	}
+	for _, p := range c.pixels {
+		j.Pixels = append(j.Pixels, p.X, p.Y, p.Z)
+	}
+	for _, a := range c.alpha {
+		if a != 1.0 {
+			j.Alpha = c.alpha
+			break
+		}
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON reads a Canvas in the MarshalJSON format.
+func (c *Canvas) UnmarshalJSON(data []byte) error {
+	var j canvasJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	// The sizes are checked against the data before allocating the Canvas,
+	// so a small payload can't claim a huge Canvas.
+	if !validCanvasSize(j.Width, j.Height) {
+		return ErrInvalidCanvasSize
+	}
+	size := j.Width * j.Height
+	if len(j.Pixels) != 3*size || (j.Alpha != nil && len(j.Alpha) != size) {
+		return ErrInvalidCanvasData
+	}
+
+	r, err := NewCanvas(j.Width, j.Height)
+	if err != nil {
+		return err
+	}
+
+	for i := range r.pixels {
+		r.pixels[i] = NewColor(j.Pixels[3*i], j.Pixels[3*i+1], j.Pixels[3*i+2])
+		if j.Alpha != nil {
+			if !(j.Alpha[i] >= 0 && j.Alpha[i] <= 1) {
+				return ErrInvalidAlpha
+			}
+			r.alpha[i] = j.Alpha[i]
+		}
+	}
+
+	*c = *r
+
+	return nil
+}
+
+// kind returns the kind of the Tuple and its values.
+func (t Tuple) kind() (string, []float64) {
+	switch {
+	case t.IsPoint():
+		return kindPoint, []float64{t.X, t.Y, t.Z}
+	case t.IsColor():
+		return kindColor, []float64{t.X, t.Y, t.Z}
+	case t.IsVector():
+		return kindVector, []float64{t.X, t.Y, t.Z}
+	}
+
+	return kindTuple, []float64{t.X, t.Y, t.Z, t.W}
+}
+
+// set changes the Tuple to the kind with the values.
+func (t *Tuple) set(kind string, values []float64) error {
+	switch kind {
+	case kindPoint:
+		*t = NewPoint(values[0], values[1], values[2])
+	case kindVector:
+		*t = NewVector(values[0], values[1], values[2])
+	case kindColor:
+		*t = NewColor(values[0], values[1], values[2])
+	case kindTuple:
+		*t = newTuple(values[0], values[1], values[2], values[3])
+	default:
+		return ErrInvalidTupleKind
+	}
+
+	return nil
+}
+
+// formatTuple returns the kind followed by the values, like "point(1, 2, 3)".
+// The values are written with the fewest digits that read back exactly.
+func formatTuple(kind string, values ...float64) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	return fmt.Sprintf("%s(%s)", kind, strings.Join(s, ", "))
+}
+
+// parseTuple reads the text written by formatTuple.
+func parseTuple(text string) (string, []float64, error) {
+	open := strings.IndexByte(text, '(')
+	if open < 0 || !strings.HasSuffix(text, ")") {
+		return "", nil, fmt.Errorf("%w: %q", ErrInvalidTupleText, text)
+	}
+
+	kind := strings.TrimSpace(text[:open])
+	fields := strings.Split(text[open+1:len(text)-1], ",")
+
+	size := 3
+	if kind == kindTuple {
+		size = 4
+	}
+	if len(fields) != size {
+		return "", nil, fmt.Errorf("%w: %q", ErrInvalidTupleText, text)
+	}
+
+	values := make([]float64, size)
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %q", ErrInvalidTupleText, text)
+		}
+		values[i] = v
+	}
+
+	switch kind {
+	case kindPoint, kindVector, kindColor, kindTuple:
+		return kind, values, nil
+	}
+
+	return "", nil, fmt.Errorf("%w: %q", ErrInvalidTupleKind, kind)
+}
+
+// parseKind reads the text written by formatTuple, checking its kind.
+func parseKind(text, want string) ([]float64, error) {
+	kind, values, err := parseTuple(text)
+	if err != nil {
+		return nil, err
+	}
+	if kind != want {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrInvalidTupleKind, want, kind)
+	}
+
+	return values, nil
+}
+
+// unmarshalTupleJSON reads a JSON object written by the MarshalJSON methods
+// or a JSON string written by the MarshalText methods.
+func unmarshalTupleJSON(data []byte) (string, []float64, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return "", nil, err
+		}
+
+		return parseTuple(text)
+	}
+
+	var j tupleJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return "", nil, err
+	}
+
+	var fields []*float64
+	switch j.Type {
+	case kindPoint, kindVector:
+		fields = []*float64{j.X, j.Y, j.Z}
+	case kindColor:
+		fields = []*float64{j.R, j.G, j.B}
+	case kindTuple:
+		fields = []*float64{j.X, j.Y, j.Z, j.W}
+	default:
+		return "", nil, fmt.Errorf("%w: %q", ErrInvalidTupleKind, j.Type)
+	}
+
+	values := make([]float64, len(fields))
+	for i, f := range fields {
+		if f == nil {
+			return "", nil, fmt.Errorf("%w: missing %s field", ErrInvalidTupleText, j.Type)
+		}
+		values[i] = *f
+	}
+
+	return j.Type, values, nil
+}
+
+// unmarshalKindJSON reads the JSON written by the MarshalJSON methods,
+// checking its kind.
+func unmarshalKindJSON(data []byte, want string) ([]float64, error) {
+	kind, values, err := unmarshalTupleJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	if kind != want {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrInvalidTupleKind, want, kind)
+	}
+
+	return values, nil
+}
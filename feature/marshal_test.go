@@ -0,0 +1,320 @@
+package feature_test
+
+import (
+	"encoding/json"
+	"errors"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestTupleMarshalText(t *testing.T) {
+	tests := []struct {
+		name  string
+		tuple feature.Tuple
+		want  string
+	}{
+		{
+			name:  "point",
+			tuple: feature.NewPoint(1, -2.5, 3),
+			want:  "point(1, -2.5, 3)",
+		},
+		{
+			name:  "vector",
+			tuple: feature.NewVector(0.1, 0, 1e-9),
+			want:  "vector(0.1, 0, 1e-09)",
+		},
+		{
+			name:  "tuple",
+			tuple: feature.Tuple{X: 1, Y: 2, Z: 3, W: 0.5},
+			want:  "tuple(1, 2, 3, 0.5)",
+		},
+		{
+			name:  "color",
+			tuple: feature.NewColor(1, 0.5, 0),
+			want:  "color(1, 0.5, 0)",
+		},
+		{
+			name:  "computed color",
+			tuple: feature.ColorRed.Mul(0.5).Neg(),
+			want:  "color(-0.5, -0, -0)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.tuple.MarshalText()
+			if err != nil {
+				t.Fatalf("%q: error marshaling: %v", test.name, err)
+			}
+			if string(got) != test.want {
+				t.Errorf("%q: wants %q and got %q", test.name, test.want, got)
+			}
+
+			var back feature.Tuple
+			if err := back.UnmarshalText(got); err != nil {
+				t.Fatalf("%q: error unmarshaling: %v", test.name, err)
+			}
+			if back != test.tuple || back.IsColor() != test.tuple.IsColor() {
+				t.Errorf("%q: wants %+v back and got %+v", test.name, test.tuple, back)
+			}
+		})
+	}
+}
+
+func TestTupleUnmarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want feature.Tuple
+		err  error
+	}{
+		{
+			name: "color",
+			text: "color(1, 0.5, 0)",
+			want: feature.NewColor(1, 0.5, 0),
+		},
+		{
+			name: "spaces",
+			text: "point( 1 ,2,  3 )",
+			want: feature.NewPoint(1, 2, 3),
+		},
+		{
+			name: "unknown kind",
+			text: "normal(1, 2, 3)",
+			err:  feature.ErrInvalidTupleKind,
+		},
+		{
+			name: "missing value",
+			text: "vector(1, 2)",
+			err:  feature.ErrInvalidTupleText,
+		},
+		{
+			name: "not a number",
+			text: "vector(1, 2, x)",
+			err:  feature.ErrInvalidTupleText,
+		},
+		{
+			name: "no parenthesis",
+			text: "1, 2, 3",
+			err:  feature.ErrInvalidTupleText,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got feature.Tuple
+			err := got.UnmarshalText([]byte(test.text))
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if !test.want.IsEqual(got) {
+				t.Errorf("%q: wants %+v and got %+v", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestTupleJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want feature.Tuple
+		err  error
+	}{
+		{
+			name: "point",
+			json: `{"type":"point","x":1,"y":2,"z":3}`,
+			want: feature.NewPoint(1, 2, 3),
+		},
+		{
+			name: "vector",
+			json: `{"type":"vector","x":0,"y":-1,"z":0}`,
+			want: feature.NewVector(0, -1, 0),
+		},
+		{
+			name: "tuple",
+			json: `{"type":"tuple","x":1,"y":2,"z":3,"w":4}`,
+			want: feature.Tuple{X: 1, Y: 2, Z: 3, W: 4},
+		},
+		{
+			name: "color",
+			json: `{"type":"color","r":1,"g":0.5,"b":0}`,
+			want: feature.NewColor(1, 0.5, 0),
+		},
+		{
+			name: "text",
+			json: `"point(1, 2, 3)"`,
+			want: feature.NewPoint(1, 2, 3),
+		},
+		{
+			name: "missing field",
+			json: `{"type":"point","x":1,"y":2}`,
+			err:  feature.ErrInvalidTupleText,
+		},
+		{
+			name: "unknown kind",
+			json: `{"type":"normal","x":1,"y":2,"z":3}`,
+			err:  feature.ErrInvalidTupleKind,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got feature.Tuple
+			err := json.Unmarshal([]byte(test.json), &got)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if got != test.want || got.IsColor() != test.want.IsColor() {
+				t.Errorf("%q: wants %+v and got %+v", test.name, test.want, got)
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("%q: error marshaling: %v", test.name, err)
+			}
+			var back feature.Tuple
+			if err := json.Unmarshal(data, &back); err != nil {
+				t.Fatalf("%q: error unmarshaling %s: %v", test.name, data, err)
+			}
+			if back != got || back.IsColor() != got.IsColor() {
+				t.Errorf("%q: wants %+v back and got %+v", test.name, got, back)
+			}
+		})
+	}
+
+	data, err := json.Marshal(feature.NewColor(1, 0.5, 0))
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+	want := `{"type":"color","r":1,"g":0.5,"b":0}`
+	if string(data) != want {
+		t.Errorf("wants %s and got %s", want, data)
+	}
+}
+
+func TestTypedJSON(t *testing.T) {
+	type scene struct {
+		From  feature.Point  `json:"from"`
+		Up    feature.Vector `json:"up"`
+		Light feature.Color  `json:"light"`
+	}
+
+	want := scene{
+		From:  feature.Pt(0, 1.5, -5),
+		Up:    feature.Vec(0, 1, 0),
+		Light: feature.RGB(1, 1, 0.9),
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+
+	expected := `{"from":{"type":"point","x":0,"y":1.5,"z":-5},"up":{"type":"vector","x":0,"y":1,"z":0},"light":{"type":"color","r":1,"g":1,"b":0.9}}`
+	if string(data) != expected {
+		t.Errorf("wants %s and got %s", expected, data)
+	}
+
+	var got scene
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("error unmarshaling: %v", err)
+	}
+	if got != want {
+		t.Errorf("wants %+v and got %+v", want, got)
+	}
+
+	// A vector can't be read as a point.
+	var p feature.Point
+	if err := json.Unmarshal([]byte(`{"type":"vector","x":0,"y":1,"z":0}`), &p); !errors.Is(err, feature.ErrInvalidTupleKind) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidTupleKind)
+	}
+	var c feature.Color
+	if err := c.UnmarshalText([]byte("point(1, 2, 3)")); !errors.Is(err, feature.ErrInvalidTupleKind) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidTupleKind)
+	}
+}
+
+func TestCanvasJSON(t *testing.T) {
+	canvas := newCanvasFrom(t, [][]feature.Tuple{{red, green, blue}})
+
+	data, err := json.Marshal(canvas)
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+
+	want := `{"width":3,"height":1,"pixels":[1,0,0,0,1,0,0,0,1]}`
+	if string(data) != want {
+		t.Errorf("wants %s and got %s", want, data)
+	}
+
+	if err := canvas.WritePixelAlpha(1, 0, green, 0.5); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+	data, err = json.Marshal(canvas)
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+
+	var got feature.Canvas
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("error unmarshaling: %v", err)
+	}
+	assertCanvas(t, "round trip", &got, [][]feature.Tuple{{red, green, blue}})
+	if _, alpha, _ := got.PixelAlpha(1, 0); alpha != 0.5 {
+		t.Errorf("wants alpha 0.5 and got %f", alpha)
+	}
+
+	tests := []struct {
+		name string
+		json string
+		err  error
+	}{
+		{
+			name: "invalid size",
+			json: `{"width":0,"height":1,"pixels":[]}`,
+			err:  feature.ErrInvalidCanvasSize,
+		},
+		{
+			name: "overflowing size",
+			json: `{"width":4294967296,"height":4294967296,"pixels":[]}`,
+			err:  feature.ErrInvalidCanvasSize,
+		},
+		{
+			name: "missing pixels",
+			json: `{"width":2,"height":1,"pixels":[1,0,0]}`,
+			err:  feature.ErrInvalidCanvasData,
+		},
+		{
+			name: "invalid alpha",
+			json: `{"width":1,"height":1,"pixels":[1,0,0],"alpha":[2]}`,
+			err:  feature.ErrInvalidAlpha,
+		},
+		{
+			name: "negative alpha",
+			json: `{"width":2,"height":1,"pixels":[1,0,0,0,1,0],"alpha":[1,-0.5]}`,
+			err:  feature.ErrInvalidAlpha,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var c feature.Canvas
+			if err := json.Unmarshal([]byte(test.json), &c); !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+		})
+	}
+
+	// JSON has no NaN, so a NaN alpha is rejected by the decoder before the
+	// alpha check.
+	var c feature.Canvas
+	if err := c.UnmarshalJSON([]byte(`{"width":1,"height":1,"pixels":[1,0,0],"alpha":[NaN]}`)); err == nil {
+		t.Errorf("expected an error for a NaN alpha")
+	}
+}
@@ -0,0 +1,67 @@
+package feature
+
+// Matrix is a 4x4 matrix in row-major order, used to transform Tuples.
+type Matrix [4][4]float64
+
+// IdentityMatrix is the Matrix that doesn't change what it multiplies.
+var IdentityMatrix = Matrix{
+	{1, 0, 0, 0},
+	{0, 1, 0, 0},
+	{0, 0, 1, 0},
+	{0, 0, 0, 1},
+}
+
+// IsEqual returns if both Matrix values are equal within the
+// DefaultTolerance.
+func (m Matrix) IsEqual(o Matrix) bool {
+	return m.IsEqualTol(o, DefaultTolerance)
+}
+
+// IsEqualTol returns if both Matrix values are equal within the Tolerance
+// tol.
+func (m Matrix) IsEqualTol(o Matrix, tol Tolerance) bool {
+	for r := range 4 {
+		for c := range 4 {
+			if !tol.Equal(m[r][c], o[r][c]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Mul is the multiplication of the Matrix by other Matrix. The result
+// applies o first and then the Matrix.
+func (m Matrix) Mul(o Matrix) Matrix {
+	var r Matrix
+	for row := range 4 {
+		for col := range 4 {
+			r[row][col] = m[row][0]*o[0][col] + m[row][1]*o[1][col] + m[row][2]*o[2][col] + m[row][3]*o[3][col]
+		}
+	}
+
+	return r
+}
+
+// MulTuple is the multiplication of the Matrix by a Tuple.
+func (m Matrix) MulTuple(t Tuple) Tuple {
+	return Tuple{
+		X: m[0][0]*t.X + m[0][1]*t.Y + m[0][2]*t.Z + m[0][3]*t.W,
+		Y: m[1][0]*t.X + m[1][1]*t.Y + m[1][2]*t.Z + m[1][3]*t.W,
+		Z: m[2][0]*t.X + m[2][1]*t.Y + m[2][2]*t.Z + m[2][3]*t.W,
+		W: m[3][0]*t.X + m[3][1]*t.Y + m[3][2]*t.Z + m[3][3]*t.W,
+	}
+}
+
+// Transpose returns the Matrix with rows and columns swapped.
+func (m Matrix) Transpose() Matrix {
+	var t Matrix
+	for r := range 4 {
+		for c := range 4 {
+			t[c][r] = m[r][c]
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,107 @@
+package feature_test
+
+import (
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestMatrixIsEqualTol(t *testing.T) {
+	m := feature.IdentityMatrix
+	m[0][3] = 0.001
+
+	tests := []struct {
+		name string
+		tol  feature.Tolerance
+		want bool
+	}{
+		{
+			name: "default",
+			tol:  feature.DefaultTolerance,
+			want: false,
+		},
+		{
+			name: "loose",
+			tol:  feature.Tolerance{Abs: 0.01},
+			want: true,
+		},
+		{
+			name: "relative",
+			tol:  feature.Tolerance{Rel: 0.01},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := m.IsEqualTol(feature.IdentityMatrix, test.tol); got != test.want {
+				t.Errorf("%q: wants %t and got %t", test.name, test.want, got)
+			}
+		})
+	}
+
+	if m.IsEqual(feature.IdentityMatrix) {
+		t.Errorf("IsEqual wants the DefaultTolerance")
+	}
+}
+
+func TestMatrixMul(t *testing.T) {
+	a := feature.Matrix{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 8, 7, 6},
+		{5, 4, 3, 2},
+	}
+	b := feature.Matrix{
+		{-2, 1, 2, 3},
+		{3, 2, 1, -1},
+		{4, 3, 6, 5},
+		{1, 2, 7, 8},
+	}
+	want := feature.Matrix{
+		{20, 22, 50, 48},
+		{44, 54, 114, 108},
+		{40, 58, 110, 102},
+		{16, 26, 46, 42},
+	}
+
+	if got := a.Mul(b); !want.IsEqual(got) {
+		t.Errorf("wants %v and got %v", want, got)
+	}
+	if got := a.Mul(feature.IdentityMatrix); !a.IsEqual(got) {
+		t.Errorf("identity wants %v and got %v", a, got)
+	}
+}
+
+func TestMatrixMulTuple(t *testing.T) {
+	m := feature.Matrix{
+		{1, 2, 3, 4},
+		{2, 4, 4, 2},
+		{8, 6, 4, 1},
+		{0, 0, 0, 1},
+	}
+
+	got := m.MulTuple(feature.NewPoint(1, 2, 3))
+	want := feature.NewPoint(18, 24, 33)
+	if !want.IsEqual(got) {
+		t.Errorf("wants %+v and got %+v", want, got)
+	}
+}
+
+func TestMatrixTranspose(t *testing.T) {
+	m := feature.Matrix{
+		{0, 9, 3, 0},
+		{9, 8, 0, 8},
+		{1, 8, 5, 3},
+		{0, 0, 5, 8},
+	}
+	want := feature.Matrix{
+		{0, 9, 1, 0},
+		{9, 8, 8, 0},
+		{3, 0, 5, 5},
+		{0, 8, 3, 8},
+	}
+
+	if got := m.Transpose(); !want.IsEqual(got) {
+		t.Errorf("wants %v and got %v", want, got)
+	}
+}
@@ -0,0 +1,58 @@
+package feature
+
+import "errors"
+
+var ErrNotPoint = errors.New("it's not a point")
+
+// Point is a position in space.
+type Point struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Pt creates a new Point.
+func Pt(x, y, z float64) Point {
+	return Point{X: x, Y: y, Z: z}
+}
+
+// Point converts the Tuple to a Point.
+// It returns an error if the Tuple isn't a point.
+func (t Tuple) Point() (Point, error) {
+	var p Point
+
+	if !t.IsPoint() {
+		return p, ErrNotPoint
+	}
+
+	p.X = t.X
+	p.Y = t.Y
+	p.Z = t.Z
+
+	return p, nil
+}
+
+// Tuple converts the Point to a point Tuple.
+func (p Point) Tuple() Tuple {
+	return NewPoint(p.X, p.Y, p.Z)
+}
+
+// IsEqual returns if both Points are the same position.
+func (p Point) IsEqual(o Point) bool {
+	return isEqual(p.X, o.X) && isEqual(p.Y, o.Y) && isEqual(p.Z, o.Z)
+}
+
+// Add moves the Point by the vector v and returns a new Point.
+func (p Point) Add(v Vector) Point {
+	return Point{X: p.X + v.X, Y: p.Y + v.Y, Z: p.Z + v.Z}
+}
+
+// Sub returns the Vector that goes from o to the Point.
+func (p Point) Sub(o Point) Vector {
+	return Vector{X: p.X - o.X, Y: p.Y - o.Y, Z: p.Z - o.Z}
+}
+
+// SubVector moves the Point backwards by the vector v and returns a new Point.
+func (p Point) SubVector(v Vector) Point {
+	return Point{X: p.X - v.X, Y: p.Y - v.Y, Z: p.Z - v.Z}
+}
@@ -0,0 +1,78 @@
+package feature_test
+
+import (
+	"errors"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestTuplePoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		tuple feature.Tuple
+		want  feature.Point
+		err   error
+	}{
+		{
+			name:  "point",
+			tuple: feature.NewPoint(1, 2, 3),
+			want:  feature.Pt(1, 2, 3),
+			err:   nil,
+		},
+		{
+			name:  "vector",
+			tuple: feature.NewVector(1, 2, 3),
+			want:  feature.Point{},
+			err:   feature.ErrNotPoint,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.tuple.Point()
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if !test.want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, got)
+			}
+			if err == nil && !got.Tuple().IsEqual(test.tuple) {
+				t.Errorf("%s wants %+v back and got %+v", test.name, test.tuple, got.Tuple())
+			}
+		})
+	}
+}
+
+func TestPointOperations(t *testing.T) {
+	p := feature.Pt(3, 2, 1)
+
+	tests := []struct {
+		name string
+		got  feature.Point
+		want feature.Point
+	}{
+		{
+			name: "add a vector",
+			got:  p.Add(feature.Vec(-2, 3, 1)),
+			want: feature.Pt(1, 5, 2),
+		},
+		{
+			name: "sub a vector",
+			got:  p.SubVector(feature.Vec(5, 6, 7)),
+			want: feature.Pt(-2, -4, -6),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.want.IsEqual(test.got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, test.got)
+			}
+		})
+	}
+
+	if got, want := p.Sub(feature.Pt(5, 6, 7)), feature.Vec(-2, -4, -6); !want.IsEqual(got) {
+		t.Errorf("sub a point wants %+v and got %+v", want, got)
+	}
+}
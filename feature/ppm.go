@@ -0,0 +1,132 @@
+package feature
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// IdentifierP6 is the identifier of the raw (binary) PPM format.
+const IdentifierP6 = "P6"
+
+var ErrInvalidPPM = errors.New("invalid PPM image")
+
+// ReadPPM reads a Canvas from a PPM image in the plain (P3) or the raw (P6)
+// format, scaling each channel by the image max color to [0, 1]. Every pixel
+// is opaque.
+// It returns an error if the image has more than MaxImagePixels pixels.
+func ReadPPM(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	identifier, err := ppmToken(br)
+	if err != nil {
+		return nil, err
+	}
+	if identifier != IdentifierP3 && identifier != IdentifierP6 {
+		return nil, fmt.Errorf("%w: unknown identifier %q", ErrInvalidPPM, identifier)
+	}
+
+	var header [3]int
+	for i, name := range [3]string{"width", "height", "max color"} {
+		header[i], err = ppmInt(br, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	width, height, maxColor := header[0], header[1], header[2]
+	if maxColor <= 0 || maxColor > 65535 {
+		return nil, fmt.Errorf("%w: invalid max color %d", ErrInvalidPPM, maxColor)
+	}
+
+	if err := checkImageSize(width, height); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+	}
+	c, err := NewCanvas(width, height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+	}
+
+	sample := func() (int, error) {
+		return ppmInt(br, "color")
+	}
+	if identifier == IdentifierP6 {
+		// A single whitespace separates the header from the binary data,
+		// and it's already read after the max color.
+		size := 1
+		if maxColor > 255 {
+			size = 2
+		}
+		buf := make([]byte, size)
+		sample = func() (int, error) {
+			if _, err := io.ReadFull(br, buf); err != nil {
+				return 0, fmt.Errorf("%w: %w", ErrInvalidPPM, err)
+			}
+			if size == 1 {
+				return int(buf[0]), nil
+			}
+			return int(buf[0])<<8 | int(buf[1]), nil
+		}
+	}
+
+	for i := range c.pixels {
+		var rgb [3]float64
+		for j := range rgb {
+			v, err := sample()
+			if err != nil {
+				return nil, err
+			}
+			if v < 0 || v > maxColor {
+				return nil, fmt.Errorf("%w: color %d out of [0, %d]", ErrInvalidPPM, v, maxColor)
+			}
+			rgb[j] = float64(v) / float64(maxColor)
+		}
+		c.pixels[i] = NewColor(rgb[0], rgb[1], rgb[2])
+	}
+
+	return c, nil
+}
+
+// ppmInt reads the next token of a PPM image as the integer name.
+func ppmInt(r *bufio.Reader, name string) (int, error) {
+	token, err := ppmToken(r)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidPPM, name, token)
+	}
+
+	return v, nil
+}
+
+// ppmToken reads the next whitespace separated token of a PPM image,
+// skipping the comments. The whitespace after the token is consumed too.
+func ppmToken(r *bufio.Reader) (string, error) {
+	var token []byte
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF && len(token) > 0 {
+			return string(token), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrInvalidPPM, io.ErrUnexpectedEOF)
+		}
+
+		switch {
+		case b == '#' && len(token) == 0:
+			if _, err := r.ReadString('\n'); err != nil && err != io.EOF {
+				return "", err
+			}
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f':
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, b)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package feature_test
+
+import (
+	"errors"
+	"ray-tracer/feature"
+	"strings"
+	"testing"
+)
+
+func TestReadPPM(t *testing.T) {
+	half := feature.NewColor(0.2, 0.6, 1)
+
+	tests := []struct {
+		name string
+		ppm  string
+		want [][]feature.Tuple
+		err  error
+	}{
+		{
+			name: "plain",
+			ppm:  "P3\n2 2\n255\n255 0 0 0 255 0\n0 0 255 255 255 255\n",
+			want: [][]feature.Tuple{{red, green}, {blue, white}},
+		},
+		{
+			name: "plain with comments and another max color",
+			ppm:  "P3\n# made by hand\n3 1 # width and height\n10\n10 0 0 2 6 10\n0 0 0",
+			want: [][]feature.Tuple{{red, half, black}},
+		},
+		{
+			name: "raw",
+			ppm:  "P6\n2 1\n255\n\xff\x00\x00\x33\x99\xff",
+			want: [][]feature.Tuple{{red, half}},
+		},
+		{
+			name: "raw 16 bits",
+			ppm:  "P6 1 1 65535\n\xff\xff\x00\x00\x00\x00",
+			want: [][]feature.Tuple{{red}},
+		},
+		{
+			name: "written by ToPPM",
+			ppm:  "P3\n1 1\n255\n0 128 255\n",
+			want: [][]feature.Tuple{{feature.NewColor(0, 128.0/255, 1)}},
+		},
+		{
+			name: "unknown identifier",
+			ppm:  "P5\n1 1\n255\n0",
+			err:  feature.ErrInvalidPPM,
+		},
+		{
+			name: "invalid size",
+			ppm:  "P3\n0 1\n255\n",
+			err:  feature.ErrInvalidCanvasSize,
+		},
+		{
+			name: "overflowing size",
+			ppm:  "P6 4294967296 4294967296 255\n",
+			err:  feature.ErrInvalidCanvasSize,
+		},
+		{
+			name: "huge size",
+			ppm:  "P6 100000 100000 255\n",
+			err:  feature.ErrImageTooLarge,
+		},
+		{
+			name: "color out of range",
+			ppm:  "P3\n1 1\n255\n0 256 0\n",
+			err:  feature.ErrInvalidPPM,
+		},
+		{
+			name: "missing pixels",
+			ppm:  "P3\n2 1\n255\n0 0 0\n",
+			err:  feature.ErrInvalidPPM,
+		},
+		{
+			name: "truncated raw data",
+			ppm:  "P6\n1 1\n255\n\x00\x00",
+			err:  feature.ErrInvalidPPM,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := feature.ReadPPM(strings.NewReader(test.ppm))
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			assertCanvas(t, test.name, got, test.want)
+		})
+	}
+}
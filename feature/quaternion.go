@@ -0,0 +1,158 @@
+package feature
+
+import "math"
+
+// Quaternion is a rotation in space, stored as W + Xi + Yj + Zk. Unlike Euler
+// angles, quaternions compose and interpolate without gimbal lock.
+type Quaternion struct {
+	W float64
+	X float64
+	Y float64
+	Z float64
+}
+
+// IdentityQuaternion is the Quaternion that doesn't rotate.
+var IdentityQuaternion = Quaternion{W: 1}
+
+// NewQuaternionFromAxisAngle creates a new Quaternion that rotates by angle
+// radians around the axis vector, like the book rotation matrices: a
+// positive angle around x turns y into z.
+// It returns an error if the axis isn't a vector or has magnitude 0.
+func NewQuaternionFromAxisAngle(axis Tuple, angle float64) (Quaternion, error) {
+	var q Quaternion
+
+	mag, err := axis.Magnitude()
+	if err != nil {
+		return q, err
+	}
+	if mag == 0 {
+		return q, ErrDivByZero
+	}
+
+	s := math.Sin(angle/2) / mag
+	q.W = math.Cos(angle / 2)
+	q.X = axis.X * s
+	q.Y = axis.Y * s
+	q.Z = axis.Z * s
+
+	return q, nil
+}
+
+// IsEqual returns if both Quaternions are the same rotation, within the
+// DefaultTolerance. As q and -q are the same rotation, both are equal.
+func (q Quaternion) IsEqual(o Quaternion) bool {
+	return q.IsEqualTol(o, DefaultTolerance)
+}
+
+// IsEqualTol returns if both Quaternions are the same rotation, within the
+// Tolerance tol.
+func (q Quaternion) IsEqualTol(o Quaternion, tol Tolerance) bool {
+	same := tol.Equal(q.W, o.W) && tol.Equal(q.X, o.X) && tol.Equal(q.Y, o.Y) && tol.Equal(q.Z, o.Z)
+	opposite := tol.Equal(q.W, -o.W) && tol.Equal(q.X, -o.X) && tol.Equal(q.Y, -o.Y) && tol.Equal(q.Z, -o.Z)
+
+	return same || opposite
+}
+
+// Mul is the composition of the Quaternion with other Quaternion. The
+// result rotates by o first and then by the Quaternion.
+func (q Quaternion) Mul(o Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*o.W - q.X*o.X - q.Y*o.Y - q.Z*o.Z,
+		X: q.W*o.X + q.X*o.W + q.Y*o.Z - q.Z*o.Y,
+		Y: q.W*o.Y - q.X*o.Z + q.Y*o.W + q.Z*o.X,
+		Z: q.W*o.Z + q.X*o.Y - q.Y*o.X + q.Z*o.W,
+	}
+}
+
+// Conjugate returns the Quaternion that rotates in the opposite direction,
+// when the Quaternion is normalized.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// Dot is the dot product of two Quaternions.
+func (q Quaternion) Dot(o Quaternion) float64 {
+	return q.W*o.W + q.X*o.X + q.Y*o.Y + q.Z*o.Z
+}
+
+// Magnitude is the length of the Quaternion.
+func (q Quaternion) Magnitude() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalize returns the Quaternion with magnitude 1.
+// It returns an error if the Quaternion has magnitude 0.
+func (q Quaternion) Normalize() (Quaternion, error) {
+	mag := q.Magnitude()
+	if mag == 0 {
+		return Quaternion{}, ErrDivByZero
+	}
+
+	return Quaternion{W: q.W / mag, X: q.X / mag, Y: q.Y / mag, Z: q.Z / mag}, nil
+}
+
+// AxisAngle returns the axis vector and the angle in radians of the
+// rotation. The identity rotation returns the x axis and angle 0.
+func (q Quaternion) AxisAngle() (Tuple, float64) {
+	w := math.Min(math.Max(q.W, -1), 1)
+	angle := 2 * math.Acos(w)
+
+	s := math.Sqrt(1 - w*w)
+	if s < 0.00001 {
+		return NewVector(1, 0, 0), 0
+	}
+
+	return NewVector(q.X/s, q.Y/s, q.Z/s), angle
+}
+
+// Rotate returns the Tuple rotated by the normalized Quaternion.
+func (q Quaternion) Rotate(t Tuple) Tuple {
+	return q.Matrix().MulTuple(t)
+}
+
+// Matrix returns the rotation Matrix of the normalized Quaternion.
+func (q Quaternion) Matrix() Matrix {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+
+	return Matrix{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Slerp is the spherical linear interpolation between the normalized
+// Quaternions a (t = 0) and b (t = 1), rotating at a constant speed along
+// the shortest path.
+func Slerp(a, b Quaternion, t float64) Quaternion {
+	cos := a.Dot(b)
+	if cos < 0 {
+		b = Quaternion{W: -b.W, X: -b.X, Y: -b.Y, Z: -b.Z}
+		cos = -cos
+	}
+
+	// Close rotations fall back to a linear interpolation, avoiding the
+	// division by a sine close to zero.
+	wa, wb := 1-t, t
+	if cos < 0.9995 {
+		theta := math.Acos(cos)
+		sin := math.Sin(theta)
+		wa = math.Sin((1-t)*theta) / sin
+		wb = math.Sin(t*theta) / sin
+	}
+
+	r := Quaternion{
+		W: wa*a.W + wb*b.W,
+		X: wa*a.X + wb*b.X,
+		Y: wa*a.Y + wb*b.Y,
+		Z: wa*a.Z + wb*b.Z,
+	}
+
+	n, err := r.Normalize()
+	if err != nil {
+		return a
+	}
+
+	return n
+}
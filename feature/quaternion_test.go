@@ -0,0 +1,235 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestNewQuaternionFromAxisAngle(t *testing.T) {
+	tests := []struct {
+		name  string
+		axis  feature.Tuple
+		angle float64
+		point feature.Tuple
+		want  feature.Tuple
+		err   error
+	}{
+		{
+			name:  "quarter around x",
+			axis:  feature.NewVector(1, 0, 0),
+			angle: math.Pi / 2,
+			point: feature.NewPoint(0, 1, 0),
+			want:  feature.NewPoint(0, 0, 1),
+		},
+		{
+			name:  "quarter around y",
+			axis:  feature.NewVector(0, 2, 0),
+			angle: math.Pi / 2,
+			point: feature.NewPoint(0, 0, 1),
+			want:  feature.NewPoint(1, 0, 0),
+		},
+		{
+			name:  "quarter around z",
+			axis:  feature.NewVector(0, 0, 1),
+			angle: math.Pi / 2,
+			point: feature.NewPoint(0, 1, 0),
+			want:  feature.NewPoint(-1, 0, 0),
+		},
+		{
+			name:  "vector",
+			axis:  feature.NewVector(1, 1, 1),
+			angle: 2 * math.Pi / 3,
+			point: feature.NewVector(1, 0, 0),
+			want:  feature.NewVector(0, 1, 0),
+		},
+		{
+			name:  "point axis",
+			axis:  feature.NewPoint(1, 0, 0),
+			angle: math.Pi,
+			err:   feature.ErrNotVector,
+		},
+		{
+			name:  "zero axis",
+			axis:  feature.NewVector(0, 0, 0),
+			angle: math.Pi,
+			err:   feature.ErrDivByZero,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := feature.NewQuaternionFromAxisAngle(test.axis, test.angle)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := q.Rotate(test.point); !test.want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestQuaternionIsEqualTol(t *testing.T) {
+	q := feature.Quaternion{W: 0.999, Y: 0.001}
+
+	tests := []struct {
+		name  string
+		other feature.Quaternion
+		tol   feature.Tolerance
+		want  bool
+	}{
+		{
+			name:  "default",
+			other: feature.IdentityQuaternion,
+			tol:   feature.DefaultTolerance,
+			want:  false,
+		},
+		{
+			name:  "loose",
+			other: feature.IdentityQuaternion,
+			tol:   feature.Tolerance{Abs: 0.01},
+			want:  true,
+		},
+		{
+			name:  "loose opposite",
+			other: feature.Quaternion{W: -1},
+			tol:   feature.Tolerance{Abs: 0.01},
+			want:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := q.IsEqualTol(test.other, test.tol); got != test.want {
+				t.Errorf("%q: wants %t and got %t", test.name, test.want, got)
+			}
+		})
+	}
+
+	if q.IsEqual(feature.IdentityQuaternion) {
+		t.Errorf("IsEqual wants the DefaultTolerance")
+	}
+}
+
+func TestQuaternionComposition(t *testing.T) {
+	x, _ := feature.NewQuaternionFromAxisAngle(feature.NewVector(1, 0, 0), math.Pi/2)
+	y, _ := feature.NewQuaternionFromAxisAngle(feature.NewVector(0, 1, 0), math.Pi/2)
+
+	// Rotating by x and then by y.
+	q := y.Mul(x)
+	p := feature.NewPoint(0, 1, 0)
+	want := y.Rotate(x.Rotate(p))
+
+	if got := q.Rotate(p); !want.IsEqual(got) {
+		t.Errorf("composition wants %+v and got %+v", want, got)
+	}
+	if got := q.Matrix(); !y.Matrix().Mul(x.Matrix()).IsEqual(got) {
+		t.Errorf("composition matrix wants %v and got %v", y.Matrix().Mul(x.Matrix()), got)
+	}
+	if got := q.Mul(q.Conjugate()); !feature.IdentityQuaternion.IsEqual(got) {
+		t.Errorf("conjugate wants %+v and got %+v", feature.IdentityQuaternion, got)
+	}
+}
+
+func TestQuaternionAxisAngle(t *testing.T) {
+	axis := feature.NewVector(0, 0.6, 0.8)
+	q, err := feature.NewQuaternionFromAxisAngle(axis, 1.2)
+	if err != nil {
+		t.Fatalf("error creating a quaternion: %v", err)
+	}
+
+	gotAxis, gotAngle := q.AxisAngle()
+	if !axis.IsEqual(gotAxis) || math.Abs(gotAngle-1.2) > 0.00001 {
+		t.Errorf("wants %+v and 1.2 and got %+v and %f", axis, gotAxis, gotAngle)
+	}
+
+	gotAxis, gotAngle = feature.IdentityQuaternion.AxisAngle()
+	if gotAngle != 0 || !gotAxis.IsVector() {
+		t.Errorf("identity wants angle 0 and got %+v and %f", gotAxis, gotAngle)
+	}
+}
+
+func TestQuaternionNormalize(t *testing.T) {
+	got, err := feature.Quaternion{W: 2}.Normalize()
+	if err != nil || !feature.IdentityQuaternion.IsEqual(got) {
+		t.Errorf("wants %+v and got %+v with error %v", feature.IdentityQuaternion, got, err)
+	}
+
+	if _, err := (feature.Quaternion{}).Normalize(); !errors.Is(err, feature.ErrDivByZero) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrDivByZero)
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	axis := feature.NewVector(0, 1, 0)
+	a, _ := feature.NewQuaternionFromAxisAngle(axis, 0)
+	b, _ := feature.NewQuaternionFromAxisAngle(axis, math.Pi/2)
+
+	tests := []struct {
+		name  string
+		a     feature.Quaternion
+		b     feature.Quaternion
+		t     float64
+		angle float64
+	}{
+		{
+			name:  "start",
+			a:     a,
+			b:     b,
+			t:     0,
+			angle: 0,
+		},
+		{
+			name:  "middle",
+			a:     a,
+			b:     b,
+			t:     0.5,
+			angle: math.Pi / 4,
+		},
+		{
+			name:  "constant speed",
+			a:     a,
+			b:     b,
+			t:     0.25,
+			angle: math.Pi / 8,
+		},
+		{
+			name:  "end",
+			a:     a,
+			b:     b,
+			t:     1,
+			angle: math.Pi / 2,
+		},
+		{
+			name:  "shortest path",
+			a:     a,
+			b:     feature.Quaternion{W: -b.W, X: -b.X, Y: -b.Y, Z: -b.Z},
+			t:     0.5,
+			angle: math.Pi / 4,
+		},
+		{
+			name:  "close rotations",
+			a:     a,
+			b:     func() feature.Quaternion { q, _ := feature.NewQuaternionFromAxisAngle(axis, 0.001); return q }(),
+			t:     0.5,
+			angle: 0.0005,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want, _ := feature.NewQuaternionFromAxisAngle(axis, test.angle)
+
+			if got := feature.Slerp(test.a, test.b, test.t); !want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, want, got)
+			}
+		})
+	}
+}
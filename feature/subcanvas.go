@@ -0,0 +1,149 @@
+package feature
+
+// SubCanvas is a rectangular view (width x height) into a Canvas, starting
+// at the parent position x and y. It shares the parent pixels, so writing to
+// a SubCanvas writes directly to the Canvas it came from.
+type SubCanvas struct {
+	parent *Canvas
+	x      int
+	y      int
+	width  int
+	height int
+}
+
+// SubCanvas returns a view of the area with width and height sizes starting
+// at the position x and y.
+// It returns an error if the area doesn't fit inside the Canvas.
+func (c *Canvas) SubCanvas(x, y, width, height int) (*SubCanvas, error) {
+	if width <= 0 || height <= 0 {
+		return nil, ErrInvalidCanvasSize
+	}
+	if x < 0 || y < 0 || x+width > c.width || y+height > c.height {
+		return nil, ErrInvalidCanvasArea
+	}
+
+	s := SubCanvas{
+		parent: c,
+		x:      x,
+		y:      y,
+		width:  width,
+		height: height,
+	}
+
+	return &s, nil
+}
+
+// Tiles splits the Canvas into SubCanvas values of tileWidth x tileHeight
+// pixels, in row-major order. Tiles in the last column and row are smaller
+// when the Canvas size isn't a multiple of the tile size.
+func (c *Canvas) Tiles(tileWidth, tileHeight int) ([]*SubCanvas, error) {
+	if tileWidth <= 0 || tileHeight <= 0 {
+		return nil, ErrInvalidCanvasSize
+	}
+
+	var tiles []*SubCanvas
+	for y := 0; y < c.height; y += tileHeight {
+		for x := 0; x < c.width; x += tileWidth {
+			s, err := c.SubCanvas(x, y, min(tileWidth, c.width-x), min(tileHeight, c.height-y))
+			if err != nil {
+				return nil, err
+			}
+
+			tiles = append(tiles, s)
+		}
+	}
+
+	return tiles, nil
+}
+
+// Size returns the SubCanvas size.
+func (s *SubCanvas) Size() int {
+	return s.width * s.height
+}
+
+// Width returns the SubCanvas width.
+func (s *SubCanvas) Width() int {
+	return s.width
+}
+
+// Height returns the SubCanvas height.
+func (s *SubCanvas) Height() int {
+	return s.height
+}
+
+// Origin returns the position of the SubCanvas inside its parent Canvas.
+func (s *SubCanvas) Origin() (int, int) {
+	return s.x, s.y
+}
+
+// Fill changes the color of every pixel of the SubCanvas, making them
+// opaque.
+func (s *SubCanvas) Fill(color Tuple) {
+	for y := range s.height {
+		start := (s.y+y)*s.parent.width + s.x
+		for i := start; i < start+s.width; i++ {
+			s.parent.pixels[i] = color
+			s.parent.alpha[i] = 1.0
+		}
+	}
+}
+
+// Pixel returns the pixel color in the position x and y, relative to the
+// SubCanvas origin.
+// It returns an error if any of the positions be invalid.
+func (s *SubCanvas) Pixel(x, y int) (Tuple, error) {
+	var p Tuple
+
+	pos, err := s.xy2pos(x, y)
+	if err != nil {
+		return p, err
+	}
+
+	p = s.parent.pixels[pos]
+
+	return p, nil
+}
+
+// PixelAlpha returns the pixel color and alpha in the position x and y,
+// relative to the SubCanvas origin.
+// It returns an error if any of the positions be invalid.
+func (s *SubCanvas) PixelAlpha(x, y int) (Tuple, float64, error) {
+	pos, err := s.xy2pos(x, y)
+	if err != nil {
+		return Tuple{}, 0, err
+	}
+
+	return s.parent.pixels[pos], s.parent.alpha[pos], nil
+}
+
+// WritePixel changes the color of a point in the position x and y, relative
+// to the SubCanvas origin, making it opaque.
+func (s *SubCanvas) WritePixel(x, y int, color Tuple) error {
+	return s.WritePixelAlpha(x, y, color, 1.0)
+}
+
+// WritePixelAlpha changes the color and alpha of a point in the position x
+// and y, relative to the SubCanvas origin.
+func (s *SubCanvas) WritePixelAlpha(x, y int, color Tuple, alpha float64) error {
+	if !(alpha >= 0 && alpha <= 1) {
+		return ErrInvalidAlpha
+	}
+
+	pos, err := s.xy2pos(x, y)
+	if err != nil {
+		return err
+	}
+
+	s.parent.pixels[pos] = color
+	s.parent.alpha[pos] = alpha
+
+	return nil
+}
+
+func (s *SubCanvas) xy2pos(x, y int) (int, error) {
+	if x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return 0, ErrInvalidCanvasPoint
+	}
+
+	return s.parent.xy2pos(s.x+x, s.y+y)
+}
@@ -0,0 +1,161 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestSubCanvas(t *testing.T) {
+	tests := []struct {
+		name   string
+		x      int
+		y      int
+		width  int
+		height int
+		err    error
+	}{
+		{
+			name:   "valid 1",
+			x:      0,
+			y:      0,
+			width:  4,
+			height: 2,
+			err:    nil,
+		},
+		{
+			name:   "valid 2",
+			x:      1,
+			y:      1,
+			width:  2,
+			height: 1,
+			err:    nil,
+		},
+		{
+			name:   "invalid size",
+			x:      0,
+			y:      0,
+			width:  0,
+			height: 1,
+			err:    feature.ErrInvalidCanvasSize,
+		},
+		{
+			name:   "invalid area 1",
+			x:      3,
+			y:      0,
+			width:  2,
+			height: 1,
+			err:    feature.ErrInvalidCanvasArea,
+		},
+		{
+			name:   "invalid area 2",
+			x:      -1,
+			y:      0,
+			width:  1,
+			height: 1,
+			err:    feature.ErrInvalidCanvasArea,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			canvas, err := feature.NewCanvas(4, 2)
+			if err != nil {
+				t.Fatalf("error creating a new canvas: %v", err)
+			}
+
+			got, err := canvas.SubCanvas(test.x, test.y, test.width, test.height)
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Width() != test.width || got.Height() != test.height {
+				t.Errorf("%q: got a sub canvas %dx%d, expected %dx%d", test.name, got.Width(), got.Height(), test.width, test.height)
+			}
+			if _, err := got.Pixel(test.width, 0); !errors.Is(err, feature.ErrInvalidCanvasPoint) {
+				t.Errorf("%q: expected error %v getting a pixel out of the sub canvas bounds but got %v", test.name, feature.ErrInvalidCanvasPoint, err)
+			}
+		})
+	}
+}
+
+func TestSubCanvasSharesPixels(t *testing.T) {
+	canvas, err := feature.NewCanvas(5, 3)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	sub, err := canvas.SubCanvas(2, 1, 3, 2)
+	if err != nil {
+		t.Fatalf("error creating a sub canvas: %v", err)
+	}
+
+	if err := sub.WritePixel(1, 1, feature.ColorRed); err != nil {
+		t.Fatalf("error writing pixel: %v", err)
+	}
+
+	got, err := canvas.Pixel(3, 2)
+	if err != nil {
+		t.Fatalf("error reading pixel: %v", err)
+	}
+	if !got.IsEqual(feature.ColorRed) {
+		t.Errorf("expected the canvas pixel to be %v but got %+v", feature.ColorRed, got)
+	}
+	if err := sub.WritePixelAlpha(0, 0, feature.ColorRed, math.NaN()); !errors.Is(err, feature.ErrInvalidAlpha) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidAlpha)
+	}
+
+	sub.Fill(feature.ColorBlue)
+	for y := range 3 {
+		for x := range 5 {
+			want := feature.ColorBlack
+			if x >= 2 && y >= 1 {
+				want = feature.ColorBlue
+			}
+
+			got, err := canvas.Pixel(x, y)
+			if err != nil {
+				t.Fatalf("error reading pixel: %v", err)
+			}
+			if !got.IsEqual(want) {
+				t.Errorf("pixel (%d, %d): expected %v but got %+v", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestTiles(t *testing.T) {
+	canvas, err := feature.NewCanvas(5, 3)
+	if err != nil {
+		t.Fatalf("error creating a new canvas: %v", err)
+	}
+
+	tiles, err := canvas.Tiles(2, 2)
+	if err != nil {
+		t.Fatalf("error splitting the canvas: %v", err)
+	}
+
+	if len(tiles) != 6 {
+		t.Fatalf("got %d tiles, expected 6", len(tiles))
+	}
+
+	size := 0
+	for _, tile := range tiles {
+		size += tile.Size()
+	}
+	if size != canvas.Size() {
+		t.Errorf("tiles cover %d pixels, expected %d", size, canvas.Size())
+	}
+
+	if x, y := tiles[5].Origin(); x != 4 || y != 2 || tiles[5].Width() != 1 || tiles[5].Height() != 1 {
+		t.Errorf("got last tile at (%d, %d) with size %dx%d, expected (4, 2) with size 1x1", x, y, tiles[5].Width(), tiles[5].Height())
+	}
+
+	if _, err := canvas.Tiles(0, 1); !errors.Is(err, feature.ErrInvalidCanvasSize) {
+		t.Errorf("got error %v, expected error %v", err, feature.ErrInvalidCanvasSize)
+	}
+}
@@ -0,0 +1,76 @@
+package feature
+
+import "math"
+
+// Tolerance defines when two floating point values are considered equal.
+// Two values are equal if they pass any of the enabled criteria, so a zero
+// field disables its criterion.
+type Tolerance struct {
+	// Abs is the largest absolute difference between equal values. It's the
+	// right choice for values close to zero.
+	Abs float64
+	// Rel is the largest difference between equal values relative to the
+	// largest magnitude of both. It scales with scenes measured in
+	// kilometres or micrometres.
+	Rel float64
+	// ULP is the largest number of representable float64 values between
+	// equal values.
+	ULP uint64
+}
+
+// DefaultTolerance is the Tolerance used by IsEqual and the other
+// comparisons that don't take one. Changing it affects the whole package,
+// so it should only be done at initialization.
+var DefaultTolerance = Tolerance{Abs: 0.00001}
+
+// Equal returns if a and b are equal within the Tolerance.
+func (tol Tolerance) Equal(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	diff := math.Abs(a - b)
+	if diff < tol.Abs {
+		return true
+	}
+	if diff <= tol.Rel*math.Max(math.Abs(a), math.Abs(b)) {
+		return true
+	}
+
+	return tol.ULP > 0 && ULPDistance(a, b) <= tol.ULP
+}
+
+// Offset returns how far a point at a distance scale from the origin has to
+// be moved to stay clear of its own surface, like the shadow acne offset.
+func (tol Tolerance) Offset(scale float64) float64 {
+	return math.Max(tol.Abs, tol.Rel*math.Abs(scale))
+}
+
+// ULPDistance returns the number of representable float64 values between a
+// and b. It returns math.MaxUint64 if any of them is NaN.
+func ULPDistance(a, b float64) uint64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.MaxUint64
+	}
+
+	ia, ib := ordered(a), ordered(b)
+	if ia > ib {
+		return uint64(ia) - uint64(ib)
+	}
+
+	return uint64(ib) - uint64(ia)
+}
+
+// ordered maps a float64 to an int64 that keeps the float64 order, so
+// consecutive values differ by one and both zeros are the same.
+func ordered(f float64) int64 {
+	i := int64(math.Float64bits(f))
+	if i < 0 {
+		return math.MinInt64 - i
+	}
+
+	return i
+}
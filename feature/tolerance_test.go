@@ -0,0 +1,167 @@
+package feature_test
+
+import (
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestToleranceEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		tol  feature.Tolerance
+		a    float64
+		b    float64
+		want bool
+	}{
+		{
+			name: "default close",
+			tol:  feature.DefaultTolerance,
+			a:    1.0,
+			b:    1.000001,
+			want: true,
+		},
+		{
+			name: "default far",
+			tol:  feature.DefaultTolerance,
+			a:    1.0,
+			b:    1.0001,
+			want: false,
+		},
+		{
+			name: "absolute fails with kilometres",
+			tol:  feature.Tolerance{Abs: 0.00001},
+			a:    1e9,
+			b:    1e9 + 1,
+			want: false,
+		},
+		{
+			name: "relative with kilometres",
+			tol:  feature.Tolerance{Rel: 1e-6},
+			a:    1e9,
+			b:    1e9 + 1,
+			want: true,
+		},
+		{
+			name: "relative with micrometres",
+			tol:  feature.Tolerance{Rel: 1e-6},
+			a:    1e-9,
+			b:    2e-9,
+			want: false,
+		},
+		{
+			name: "ulp",
+			tol:  feature.Tolerance{ULP: 4},
+			a:    1.0,
+			b:    math.Nextafter(math.Nextafter(1.0, 2), 2),
+			want: true,
+		},
+		{
+			name: "ulp across zero",
+			tol:  feature.Tolerance{ULP: 2},
+			a:    math.SmallestNonzeroFloat64,
+			b:    -math.SmallestNonzeroFloat64,
+			want: true,
+		},
+		{
+			name: "ulp far",
+			tol:  feature.Tolerance{ULP: 4},
+			a:    1.0,
+			b:    1.0000001,
+			want: false,
+		},
+		{
+			name: "nan",
+			tol:  feature.Tolerance{Abs: 1, Rel: 1, ULP: 1},
+			a:    math.NaN(),
+			b:    math.NaN(),
+			want: false,
+		},
+		{
+			name: "infinity",
+			tol:  feature.DefaultTolerance,
+			a:    math.Inf(1),
+			b:    math.Inf(1),
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.tol.Equal(test.a, test.b); got != test.want {
+				t.Errorf("%q: Equal(%g, %g) wants %v and got %v", test.name, test.a, test.b, test.want, got)
+			}
+		})
+	}
+}
+
+func TestULPDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    float64
+		b    float64
+		want uint64
+	}{
+		{
+			name: "same",
+			a:    1.5,
+			b:    1.5,
+			want: 0,
+		},
+		{
+			name: "zeros",
+			a:    0,
+			b:    math.Copysign(0, -1),
+			want: 0,
+		},
+		{
+			name: "next",
+			a:    1.0,
+			b:    math.Nextafter(1.0, 2),
+			want: 1,
+		},
+		{
+			name: "negative",
+			a:    -1.0,
+			b:    math.Nextafter(-1.0, -2),
+			want: 1,
+		},
+		{
+			name: "nan",
+			a:    math.NaN(),
+			b:    1,
+			want: math.MaxUint64,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := feature.ULPDistance(test.a, test.b); got != test.want {
+				t.Errorf("%q: wants %d and got %d", test.name, test.want, got)
+			}
+		})
+	}
+}
+
+func TestToleranceOffset(t *testing.T) {
+	tol := feature.Tolerance{Abs: 0.0001, Rel: 1e-6}
+
+	if got := tol.Offset(1); got != 0.0001 {
+		t.Errorf("small scene wants offset 0.0001 and got %g", got)
+	}
+	if got := tol.Offset(-1e6); got != 1 {
+		t.Errorf("large scene wants offset 1 and got %g", got)
+	}
+}
+
+func TestIsEqualTol(t *testing.T) {
+	a := feature.NewPoint(1e6, 2e6, 3e6)
+	b := feature.NewPoint(1e6+0.5, 2e6, 3e6)
+
+	if a.IsEqual(b) {
+		t.Errorf("expected %v and %v to differ with the default tolerance", a, b)
+	}
+	if !a.IsEqualTol(b, feature.Tolerance{Rel: 1e-6}) {
+		t.Errorf("expected %v and %v to be equal with a relative tolerance", a, b)
+	}
+}
@@ -0,0 +1,71 @@
+package feature
+
+import "math"
+
+// Translation returns the Matrix that moves points by x, y and z. Vectors
+// aren't changed.
+func Translation(x, y, z float64) Matrix {
+	m := IdentityMatrix
+	m[0][3], m[1][3], m[2][3] = x, y, z
+
+	return m
+}
+
+// Scaling returns the Matrix that scales tuples by x, y and z.
+func Scaling(x, y, z float64) Matrix {
+	m := IdentityMatrix
+	m[0][0], m[1][1], m[2][2] = x, y, z
+
+	return m
+}
+
+// RotationX returns the Matrix that rotates tuples by r radians around the x
+// axis, clockwise when looking from the axis towards the origin.
+func RotationX(r float64) Matrix {
+	sin, cos := math.Sincos(r)
+
+	return Matrix{
+		{1, 0, 0, 0},
+		{0, cos, -sin, 0},
+		{0, sin, cos, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// RotationY returns the Matrix that rotates tuples by r radians around the y
+// axis.
+func RotationY(r float64) Matrix {
+	sin, cos := math.Sincos(r)
+
+	return Matrix{
+		{cos, 0, sin, 0},
+		{0, 1, 0, 0},
+		{-sin, 0, cos, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// RotationZ returns the Matrix that rotates tuples by r radians around the z
+// axis.
+func RotationZ(r float64) Matrix {
+	sin, cos := math.Sincos(r)
+
+	return Matrix{
+		{cos, -sin, 0, 0},
+		{sin, cos, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Shearing returns the Matrix that moves each component of a tuple in
+// proportion to the other two: xy is how much x moves in proportion to y,
+// and so on.
+func Shearing(xy, xz, yx, yz, zx, zy float64) Matrix {
+	return Matrix{
+		{1, xy, xz, 0},
+		{yx, 1, yz, 0},
+		{zx, zy, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
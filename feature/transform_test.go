@@ -0,0 +1,87 @@
+package feature_test
+
+import (
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform feature.Matrix
+		tuple     feature.Tuple
+		want      feature.Tuple
+	}{
+		{
+			name:      "translation moves a point",
+			transform: feature.Translation(5, -3, 2),
+			tuple:     feature.NewPoint(-3, 4, 5),
+			want:      feature.NewPoint(2, 1, 7),
+		},
+		{
+			name:      "translation doesn't move a vector",
+			transform: feature.Translation(5, -3, 2),
+			tuple:     feature.NewVector(-3, 4, 5),
+			want:      feature.NewVector(-3, 4, 5),
+		},
+		{
+			name:      "scaling a point",
+			transform: feature.Scaling(2, 3, 4),
+			tuple:     feature.NewPoint(-4, 6, 8),
+			want:      feature.NewPoint(-8, 18, 32),
+		},
+		{
+			name:      "reflection is a negative scaling",
+			transform: feature.Scaling(-1, 1, 1),
+			tuple:     feature.NewPoint(2, 3, 4),
+			want:      feature.NewPoint(-2, 3, 4),
+		},
+		{
+			name:      "rotation around x",
+			transform: feature.RotationX(math.Pi / 4),
+			tuple:     feature.NewPoint(0, 1, 0),
+			want:      feature.NewPoint(0, math.Sqrt2/2, math.Sqrt2/2),
+		},
+		{
+			name:      "rotation around y",
+			transform: feature.RotationY(math.Pi / 2),
+			tuple:     feature.NewPoint(0, 0, 1),
+			want:      feature.NewPoint(1, 0, 0),
+		},
+		{
+			name:      "rotation around z",
+			transform: feature.RotationZ(math.Pi / 2),
+			tuple:     feature.NewPoint(0, 1, 0),
+			want:      feature.NewPoint(-1, 0, 0),
+		},
+		{
+			name:      "shearing x in proportion to y",
+			transform: feature.Shearing(1, 0, 0, 0, 0, 0),
+			tuple:     feature.NewPoint(2, 3, 4),
+			want:      feature.NewPoint(5, 3, 4),
+		},
+		{
+			name:      "shearing z in proportion to y",
+			transform: feature.Shearing(0, 0, 0, 0, 0, 1),
+			tuple:     feature.NewPoint(2, 3, 4),
+			want:      feature.NewPoint(2, 3, 7),
+		},
+		{
+			name:      "chained in reverse order",
+			transform: feature.Translation(10, 5, 7).Mul(feature.Scaling(5, 5, 5)).Mul(feature.RotationX(math.Pi / 2)),
+			tuple:     feature.NewPoint(1, 0, 1),
+			want:      feature.NewPoint(15, 0, 7),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.transform.MulTuple(test.tuple)
+
+			if !test.want.IsEqual(got) {
+				t.Errorf("%q: wants %v and got %v", test.name, test.want, got)
+			}
+		})
+	}
+}
@@ -21,6 +21,11 @@ var (
 
 // Tuple is the primitive type for any ray tracing operations.
 // A Tuple can be a point if it has w = 1.0 or a vector if it has w = 0.0.
+// The colors created by NewColor are vectors with w = -0.0, which compares
+// equal to 0.0 but lets them print and marshal as "color(r, g, b)"; the
+// arithmetic keeps the sign.
+// The Point, Vector and Color types hold a single kind of Tuple, so using
+// them in the wrong operation is caught at compile time.
 type Tuple struct {
 	X float64
 	Y float64
@@ -28,6 +33,9 @@ type Tuple struct {
 	W float64
 }
 
+// colorW is the w of the colors.
+var colorW = math.Copysign(0, -1)
+
 // NewPoint creates a new point tuple.
 func NewPoint(x, y, z float64) Tuple {
 	return newTuple(x, y, z, 1.0)
@@ -40,7 +48,7 @@ func NewVector(x, y, z float64) Tuple {
 
 // NewColor creates a new color tuple.
 func NewColor(r, g, b float64) Tuple {
-	return newTuple(r, g, b, 0.0)
+	return newTuple(r, g, b, colorW)
 }
 
 func newTuple(x, y, z, w float64) Tuple {
@@ -52,9 +60,9 @@ func newTuple(x, y, z, w float64) Tuple {
 	}
 }
 
-// String is the string representation of the Tuple.
+// String is the string representation of the Tuple, like "point(1, 2, 3)".
 func (t Tuple) String() string {
-	return fmt.Sprintf("proj new position: x[%f] y[%f] z[%f] w [%f]", t.X, t.Y, t.Z, t.W)
+	return fmt.Sprint(t)
 }
 
 // ColorString is the string representation of the Color.
@@ -71,14 +79,36 @@ func (t Tuple) IsPoint() bool {
 	return t.W == 1.0
 }
 
-// IsVector returns if the Tuple is a vector.
+// IsVector returns if the Tuple is a vector. Colors are vectors too.
 func (t Tuple) IsVector() bool {
 	return t.W == 0.0
 }
 
-// IsVector returns if the Tuple is a vector.
+// IsColor returns if the Tuple is a color created by NewColor, or computed
+// from one.
+func (t Tuple) IsColor() bool {
+	return t.W == 0.0 && math.Signbit(t.W)
+}
+
+// keepW returns w, the result of some arithmetic on the Tuple with w = tw.
+// When both are zero it returns tw, so the -0.0 of the colors and the 0.0 of
+// the vectors keep their sign.
+func keepW(w, tw float64) float64 {
+	if w == 0 && tw == 0 {
+		return tw
+	}
+
+	return w
+}
+
+// IsEqual returns if both Tuples are equal within the DefaultTolerance.
 func (t Tuple) IsEqual(o Tuple) bool {
-	return isEqual(t.X, o.X) && isEqual(t.Y, o.Y) && isEqual(t.Z, o.Z) && isEqual(t.W, o.W)
+	return t.IsEqualTol(o, DefaultTolerance)
+}
+
+// IsEqualTol returns if both Tuples are equal within the Tolerance tol.
+func (t Tuple) IsEqualTol(o Tuple, tol Tolerance) bool {
+	return tol.Equal(t.X, o.X) && tol.Equal(t.Y, o.Y) && tol.Equal(t.Z, o.Z) && tol.Equal(t.W, o.W)
 }
 
 // Add sums two Tuple values and returns a new Tuple.
@@ -90,12 +120,7 @@ func (t Tuple) Add(o Tuple) (Tuple, error) {
 		return r, ErrAddTwoPoints
 	}
 
-	r.X = t.X + o.X
-	r.Y = t.Y + o.Y
-	r.Z = t.Z + o.Z
-	r.W = t.W + o.W
-
-	return r, nil
+	return t.AddUnchecked(o), nil
 }
 
 // Sub subtracts two Tuple values and returns a new Tuple.
@@ -107,12 +132,7 @@ func (t Tuple) Sub(o Tuple) (Tuple, error) {
 		return r, ErrSubPointFromVector
 	}
 
-	r.X = t.X - o.X
-	r.Y = t.Y - o.Y
-	r.Z = t.Z - o.Z
-	r.W = t.W - o.W
-
-	return r, nil
+	return t.SubUnchecked(o), nil
 }
 
 // Neg is the opposite of a tuple. It subtracts the vector from (0, 0, 0, 0).
@@ -121,7 +141,7 @@ func (t Tuple) Neg() Tuple {
 		X: -t.X,
 		Y: -t.Y,
 		Z: -t.Z,
-		W: -t.W,
+		W: keepW(-t.W, t.W),
 	}
 }
 
@@ -131,7 +151,7 @@ func (t Tuple) Mul(s float64) Tuple {
 		X: t.X * s,
 		Y: t.Y * s,
 		Z: t.Z * s,
-		W: t.W * s,
+		W: keepW(t.W*s, t.W),
 	}
 }
 
@@ -143,12 +163,7 @@ func (t Tuple) Div(s float64) (Tuple, error) {
 		return r, ErrDivByZero
 	}
 
-	r.X = t.X / s
-	r.Y = t.Y / s
-	r.Z = t.Z / s
-	r.W = t.W / s
-
-	return r, nil
+	return t.DivUnchecked(s), nil
 }
 
 // DotProduct is the multiplication of a vector by other vector and returns a scalar.
@@ -159,9 +174,7 @@ func (t Tuple) DotProduct(o Tuple) (float64, error) {
 		return s, ErrNotVector
 	}
 
-	s = t.X*o.X + t.Y*o.Y + t.Z*o.Z + t.W*o.W
-
-	return s, nil
+	return t.DotProductUnchecked(o), nil
 }
 
 // CrossProduct is the multiplication of a vector by other vector and returns a vector.
@@ -172,20 +185,15 @@ func (t Tuple) CrossProduct(o Tuple) (Tuple, error) {
 		return c, ErrNotVector
 	}
 
-	c.X = t.Y*o.Z - t.Z*o.Y
-	c.Y = t.Z*o.X - t.X*o.Z
-	c.Z = t.X*o.Y - t.Y*o.X
-
-	return c, nil
+	return t.CrossProductUnchecked(o), nil
 }
 
 // HadamardProduct is the multiplication of a color by other color and returns a color.
 func (t Tuple) HadamardProduct(o Tuple) Tuple {
-	return newTuple(
+	return NewColor(
 		t.X*o.X,
 		t.Y*o.Y,
 		t.Z*o.Z,
-		0.0,
 	)
 }
 
@@ -197,9 +205,7 @@ func (t Tuple) Magnitude() (float64, error) {
 		return m, ErrNotVector
 	}
 
-	m = math.Sqrt(math.Pow(t.X, 2.0) + math.Pow(t.Y, 2.0) + math.Pow(t.Z, 2.0) + math.Pow(t.W, 2.0))
-
-	return m, nil
+	return t.MagnitudeUnchecked(), nil
 }
 
 // Normalize is the normalization of a vector.
@@ -210,23 +216,11 @@ func (t Tuple) Normalize() (Tuple, error) {
 		return n, ErrNotVector
 	}
 
-	mag, err := t.Magnitude()
-	if err != nil {
-		return n, err
-	}
-
-	n.X = t.X / mag
-	n.Y = t.Y / mag
-	n.Z = t.Z / mag
-	n.W = t.W / mag
-
-	return n, nil
+	return t.NormalizeUnchecked(), nil
 }
 
 func isEqual(a, b float64) bool {
-	const EPSILON = 0.00001
-
-	return math.Abs(a-b) < EPSILON
+	return DefaultTolerance.Equal(a, b)
 }
 
 func clamp(value float64, max int) int {
@@ -0,0 +1,31 @@
+package feature
+
+// Tuple32 is a Tuple stored with float32 values, taking half the memory.
+// It's meant for storage, like mesh vertices; operations should convert it
+// back to a Tuple.
+type Tuple32 struct {
+	X float32
+	Y float32
+	Z float32
+	W float32
+}
+
+// Tuple32 converts the Tuple to a Tuple32, losing precision.
+func (t Tuple) Tuple32() Tuple32 {
+	return Tuple32{
+		X: float32(t.X),
+		Y: float32(t.Y),
+		Z: float32(t.Z),
+		W: float32(t.W),
+	}
+}
+
+// Tuple converts the Tuple32 to a Tuple.
+func (t Tuple32) Tuple() Tuple {
+	return Tuple{
+		X: float64(t.X),
+		Y: float64(t.Y),
+		Z: float64(t.Z),
+		W: float64(t.W),
+	}
+}
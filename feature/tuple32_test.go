@@ -0,0 +1,23 @@
+package feature_test
+
+import (
+	"ray-tracer/feature"
+	"testing"
+	"unsafe"
+)
+
+func TestTuple32(t *testing.T) {
+	tuple := feature.NewPoint(1.5, -2.25, 1.0/3)
+
+	got := tuple.Tuple32().Tuple()
+	if !tuple.IsEqual(got) {
+		t.Errorf("wants %+v and got %+v", tuple, got)
+	}
+	if !got.IsPoint() {
+		t.Errorf("expected a point, got %+v", got)
+	}
+
+	if size, full := unsafe.Sizeof(feature.Tuple32{}), unsafe.Sizeof(feature.Tuple{}); 2*size != full {
+		t.Errorf("expected a Tuple32 with half the %d bytes of a Tuple, got %d bytes", full, size)
+	}
+}
@@ -121,6 +121,76 @@ func TestNewColor(t *testing.T) {
 	}
 }
 
+func TestIsColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		tuple feature.Tuple
+		want  bool
+	}{
+		{
+			name:  "color",
+			tuple: feature.NewColor(1, 0.5, 0),
+			want:  true,
+		},
+		{
+			name:  "vector",
+			tuple: feature.NewVector(1, 0.5, 0),
+			want:  false,
+		},
+		{
+			name:  "point",
+			tuple: feature.NewPoint(1, 0.5, 0),
+			want:  false,
+		},
+		{
+			name:  "sum of colors",
+			tuple: feature.ColorRed.AddUnchecked(feature.ColorBlue),
+			want:  true,
+		},
+		{
+			name:  "difference of colors",
+			tuple: feature.ColorRed.SubUnchecked(feature.ColorRed),
+			want:  true,
+		},
+		{
+			name:  "scaled color",
+			tuple: feature.ColorWhite.Mul(0).DivUnchecked(-2),
+			want:  true,
+		},
+		{
+			name:  "negated color",
+			tuple: feature.ColorGreen.Neg(),
+			want:  true,
+		},
+		{
+			name:  "negated vector",
+			tuple: feature.NewVector(1, 0, 0).Neg(),
+			want:  false,
+		},
+		{
+			name:  "scaled vector",
+			tuple: feature.NewVector(1, 0, 0).Mul(-1),
+			want:  false,
+		},
+		{
+			name:  "hadamard product",
+			tuple: feature.NewVector(1, 1, 1).HadamardProduct(feature.NewVector(1, 0, 0)),
+			want:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.tuple.IsColor(); got != test.want {
+				t.Errorf("%q: wants %t and got %t", test.name, test.want, got)
+			}
+			if !test.tuple.IsPoint() && !test.tuple.IsVector() {
+				t.Errorf("%q: wants a point or a vector and got %+v", test.name, test.tuple)
+			}
+		})
+	}
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,52 @@
+package feature
+
+import "math"
+
+// The Unchecked methods are the fast path of the Tuple arithmetic, meant for
+// the inner loops of the ray tracer. They skip the point and vector checks
+// and never return errors, so they are small enough to be inlined and don't
+// allocate. The caller is responsible for passing valid Tuples; for example,
+// DivUnchecked by zero returns infinite or NaN values.
+
+// AddUnchecked sums two Tuple values without checking their kinds.
+func (t Tuple) AddUnchecked(o Tuple) Tuple {
+	return Tuple{X: t.X + o.X, Y: t.Y + o.Y, Z: t.Z + o.Z, W: keepW(t.W+o.W, t.W)}
+}
+
+// SubUnchecked subtracts two Tuple values without checking their kinds.
+func (t Tuple) SubUnchecked(o Tuple) Tuple {
+	return Tuple{X: t.X - o.X, Y: t.Y - o.Y, Z: t.Z - o.Z, W: keepW(t.W-o.W, t.W)}
+}
+
+// DivUnchecked divides the Tuple by a scalar without checking for zero.
+func (t Tuple) DivUnchecked(s float64) Tuple {
+	return Tuple{X: t.X / s, Y: t.Y / s, Z: t.Z / s, W: keepW(t.W/s, t.W)}
+}
+
+// DotProductUnchecked is the dot product of two Tuples without checking
+// that both are vectors.
+func (t Tuple) DotProductUnchecked(o Tuple) float64 {
+	return t.X*o.X + t.Y*o.Y + t.Z*o.Z + t.W*o.W
+}
+
+// CrossProductUnchecked is the cross product of two Tuples without checking
+// that both are vectors.
+func (t Tuple) CrossProductUnchecked(o Tuple) Tuple {
+	return Tuple{
+		X: t.Y*o.Z - t.Z*o.Y,
+		Y: t.Z*o.X - t.X*o.Z,
+		Z: t.X*o.Y - t.Y*o.X,
+	}
+}
+
+// MagnitudeUnchecked is the magnitude of the Tuple without checking that it
+// is a vector.
+func (t Tuple) MagnitudeUnchecked() float64 {
+	return math.Sqrt(t.X*t.X + t.Y*t.Y + t.Z*t.Z + t.W*t.W)
+}
+
+// NormalizeUnchecked is the normalization of the Tuple without checking that
+// it is a vector.
+func (t Tuple) NormalizeUnchecked() Tuple {
+	return t.DivUnchecked(t.MagnitudeUnchecked())
+}
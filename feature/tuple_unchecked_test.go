@@ -0,0 +1,165 @@
+package feature_test
+
+import (
+	"os/exec"
+	"ray-tracer/feature"
+	"strings"
+	"testing"
+)
+
+func TestUnchecked(t *testing.T) {
+	p := feature.NewPoint(3, -2, 5)
+	v := feature.NewVector(-2, 3, 1)
+	u := feature.NewVector(1, 2, 3)
+
+	tests := []struct {
+		name string
+		got  feature.Tuple
+		want feature.Tuple
+	}{
+		{
+			name: "add",
+			got:  p.AddUnchecked(v),
+			want: feature.NewPoint(1, 1, 6),
+		},
+		{
+			name: "sub",
+			got:  p.SubUnchecked(v),
+			want: feature.NewPoint(5, -5, 4),
+		},
+		{
+			name: "div",
+			got:  u.DivUnchecked(2),
+			want: feature.NewVector(0.5, 1, 1.5),
+		},
+		{
+			name: "cross product",
+			got:  u.CrossProductUnchecked(feature.NewVector(2, 3, 4)),
+			want: feature.NewVector(-1, 2, -1),
+		},
+		{
+			name: "normalize",
+			got:  u.NormalizeUnchecked(),
+			want: feature.NewVector(0.26726, 0.53452, 0.80178),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.want.IsEqual(test.got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, test.got)
+			}
+		})
+	}
+
+	if got := u.DotProductUnchecked(feature.NewVector(2, 3, 4)); got != 20 {
+		t.Errorf("dot product wants 20 and got %f", got)
+	}
+	if got := feature.NewVector(0, 0, 5).MagnitudeUnchecked(); got != 5 {
+		t.Errorf("magnitude wants 5 and got %f", got)
+	}
+}
+
+func TestUncheckedAllocations(t *testing.T) {
+	p := feature.NewPoint(3, -2, 5)
+	v := feature.NewVector(-2, 3, 1)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		r := p.AddUnchecked(v).SubUnchecked(v).DivUnchecked(2)
+		n := r.CrossProductUnchecked(v).NormalizeUnchecked()
+		_ = n.DotProductUnchecked(v) + n.MagnitudeUnchecked()
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations but got %f per run", allocs)
+	}
+}
+
+func TestUncheckedInlining(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the compiler inlining report in short mode")
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+
+	out, err := exec.Command(goBin, "build", "-gcflags=-m", ".").CombinedOutput()
+	if err != nil {
+		t.Fatalf("error building with the inlining report: %v\n%s", err, out)
+	}
+
+	for _, method := range []string{
+		"AddUnchecked",
+		"SubUnchecked",
+		"DivUnchecked",
+		"DotProductUnchecked",
+		"CrossProductUnchecked",
+		"MagnitudeUnchecked",
+		"NormalizeUnchecked",
+	} {
+		if !strings.Contains(string(out), "can inline Tuple."+method) {
+			t.Errorf("expected Tuple.%s to be inlined", method)
+		}
+	}
+}
+
+var benchResult feature.Tuple
+
+func BenchmarkAdd(b *testing.B) {
+	p := feature.NewPoint(3, -2, 5)
+	v := feature.NewVector(-2, 3, 1)
+
+	b.ReportAllocs()
+	for range b.N {
+		benchResult, _ = p.Add(v)
+	}
+}
+
+func BenchmarkAddUnchecked(b *testing.B) {
+	p := feature.NewPoint(3, -2, 5)
+	v := feature.NewVector(-2, 3, 1)
+
+	b.ReportAllocs()
+	for range b.N {
+		benchResult = p.AddUnchecked(v)
+	}
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	v := feature.NewVector(1, 2, 3)
+
+	b.ReportAllocs()
+	for range b.N {
+		benchResult, _ = v.Normalize()
+	}
+}
+
+func BenchmarkNormalizeUnchecked(b *testing.B) {
+	v := feature.NewVector(1, 2, 3)
+
+	b.ReportAllocs()
+	for range b.N {
+		benchResult = v.NormalizeUnchecked()
+	}
+}
+
+func BenchmarkCrossProduct(b *testing.B) {
+	u := feature.NewVector(1, 2, 3)
+	v := feature.NewVector(2, 3, 4)
+
+	b.ReportAllocs()
+	for range b.N {
+		benchResult, _ = u.CrossProduct(v)
+	}
+}
+
+func BenchmarkCrossProductUnchecked(b *testing.B) {
+	u := feature.NewVector(1, 2, 3)
+	v := feature.NewVector(2, 3, 4)
+
+	b.ReportAllocs()
+	for range b.N {
+		benchResult = u.CrossProductUnchecked(v)
+	}
+}
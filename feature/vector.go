@@ -0,0 +1,95 @@
+package feature
+
+import "math"
+
+// Vector is a direction with a magnitude.
+type Vector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Vec creates a new Vector.
+func Vec(x, y, z float64) Vector {
+	return Vector{X: x, Y: y, Z: z}
+}
+
+// Vector converts the Tuple to a Vector.
+// It returns an error if the Tuple isn't a vector.
+func (t Tuple) Vector() (Vector, error) {
+	var v Vector
+
+	if !t.IsVector() {
+		return v, ErrNotVector
+	}
+
+	v.X = t.X
+	v.Y = t.Y
+	v.Z = t.Z
+
+	return v, nil
+}
+
+// Tuple converts the Vector to a vector Tuple.
+func (v Vector) Tuple() Tuple {
+	return NewVector(v.X, v.Y, v.Z)
+}
+
+// IsEqual returns if both Vectors are the same.
+func (v Vector) IsEqual(o Vector) bool {
+	return isEqual(v.X, o.X) && isEqual(v.Y, o.Y) && isEqual(v.Z, o.Z)
+}
+
+// Add sums two Vectors and returns a new Vector.
+func (v Vector) Add(o Vector) Vector {
+	return Vector{X: v.X + o.X, Y: v.Y + o.Y, Z: v.Z + o.Z}
+}
+
+// Sub subtracts two Vectors and returns a new Vector.
+func (v Vector) Sub(o Vector) Vector {
+	return Vector{X: v.X - o.X, Y: v.Y - o.Y, Z: v.Z - o.Z}
+}
+
+// Neg is the opposite of the Vector.
+func (v Vector) Neg() Vector {
+	return Vector{X: -v.X, Y: -v.Y, Z: -v.Z}
+}
+
+// Mul is the multiplication of the Vector by a scalar.
+func (v Vector) Mul(s float64) Vector {
+	return Vector{X: v.X * s, Y: v.Y * s, Z: v.Z * s}
+}
+
+// Div is the division of the Vector by a scalar.
+func (v Vector) Div(s float64) (Vector, error) {
+	if s == 0.0 {
+		return Vector{}, ErrDivByZero
+	}
+
+	return Vector{X: v.X / s, Y: v.Y / s, Z: v.Z / s}, nil
+}
+
+// Dot is the dot product of two Vectors.
+func (v Vector) Dot(o Vector) float64 {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z
+}
+
+// Cross is the cross product of two Vectors.
+func (v Vector) Cross(o Vector) Vector {
+	return Vector{
+		X: v.Y*o.Z - v.Z*o.Y,
+		Y: v.Z*o.X - v.X*o.Z,
+		Z: v.X*o.Y - v.Y*o.X,
+	}
+}
+
+// Magnitude is the length of the Vector.
+func (v Vector) Magnitude() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Normalize returns a Vector with the same direction and magnitude 1.
+// It returns an error if the Vector has magnitude 0.
+func (v Vector) Normalize() (Vector, error) {
+	return v.Div(v.Magnitude())
+}
@@ -0,0 +1,138 @@
+package feature_test
+
+import (
+	"errors"
+	"math"
+	"ray-tracer/feature"
+	"testing"
+)
+
+func TestTupleVector(t *testing.T) {
+	tests := []struct {
+		name  string
+		tuple feature.Tuple
+		want  feature.Vector
+		err   error
+	}{
+		{
+			name:  "vector",
+			tuple: feature.NewVector(1, 2, 3),
+			want:  feature.Vec(1, 2, 3),
+			err:   nil,
+		},
+		{
+			name:  "point",
+			tuple: feature.NewPoint(1, 2, 3),
+			want:  feature.Vector{},
+			err:   feature.ErrNotVector,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.tuple.Vector()
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if !test.want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, got)
+			}
+			if err == nil && !got.Tuple().IsEqual(test.tuple) {
+				t.Errorf("%s wants %+v back and got %+v", test.name, test.tuple, got.Tuple())
+			}
+		})
+	}
+}
+
+func TestVectorOperations(t *testing.T) {
+	a := feature.Vec(1, 2, 3)
+	b := feature.Vec(2, 3, 4)
+
+	tests := []struct {
+		name string
+		got  feature.Vector
+		want feature.Vector
+	}{
+		{
+			name: "add",
+			got:  a.Add(b),
+			want: feature.Vec(3, 5, 7),
+		},
+		{
+			name: "sub",
+			got:  a.Sub(b),
+			want: feature.Vec(-1, -1, -1),
+		},
+		{
+			name: "neg",
+			got:  a.Neg(),
+			want: feature.Vec(-1, -2, -3),
+		},
+		{
+			name: "mul",
+			got:  a.Mul(3.5),
+			want: feature.Vec(3.5, 7, 10.5),
+		},
+		{
+			name: "cross 1",
+			got:  a.Cross(b),
+			want: feature.Vec(-1, 2, -1),
+		},
+		{
+			name: "cross 2",
+			got:  b.Cross(a),
+			want: feature.Vec(1, -2, 1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.want.IsEqual(test.got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, test.got)
+			}
+		})
+	}
+
+	if got := a.Dot(b); got != 20 {
+		t.Errorf("dot wants 20 and got %f", got)
+	}
+	if got := a.Magnitude(); math.Abs(got-math.Sqrt(14)) > 0.00001 {
+		t.Errorf("magnitude wants %f and got %f", math.Sqrt(14), got)
+	}
+}
+
+func TestVectorDivAndNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		vector feature.Vector
+		want   feature.Vector
+		err    error
+	}{
+		{
+			name:   "normalize",
+			vector: feature.Vec(1, 2, 3),
+			want:   feature.Vec(0.26726, 0.53452, 0.80178),
+			err:    nil,
+		},
+		{
+			name:   "zero vector",
+			vector: feature.Vec(0, 0, 0),
+			want:   feature.Vector{},
+			err:    feature.ErrDivByZero,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.vector.Normalize()
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.name, err, test.err)
+			}
+			if !test.want.IsEqual(got) {
+				t.Errorf("%s wants %+v and got %+v", test.name, test.want, got)
+			}
+		})
+	}
+}
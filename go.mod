@@ -2,7 +2,10 @@ module ray-tracer
 
 go 1.22.1
 
-require github.com/kr/pretty v0.3.1
+require (
+	github.com/kr/pretty v0.3.1
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/kr/text v0.2.0 // indirect
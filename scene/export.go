@@ -0,0 +1,461 @@
+package scene
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// object is a map that keeps the order of its keys. The scene is first
+// turned into objects, lists and values, and then written as YAML or JSON.
+type object []field
+
+type field struct {
+	key   string
+	value any
+}
+
+// ToYAML writes the scene to w in the format read by ParseYAML. The values
+// equal to the defaults are left out.
+// It returns an error if the loaders would reject the scene.
+func (s *Scene) ToYAML(w io.Writer) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	root := &yaml.Node{Kind: yaml.SequenceNode}
+
+	if s.Camera != nil {
+		root.Content = append(root.Content, yamlNode(append(object{{"add", "camera"}}, cameraObject(*s.Camera)...)))
+	}
+	for _, l := range s.Lights {
+		root.Content = append(root.Content, yamlNode(append(object{{"add", "light"}}, lightObject(l)...)))
+	}
+	for _, shape := range s.Shapes {
+		root.Content = append(root.Content, yamlNode(shapeObject(shape, "add")))
+	}
+
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+	if err := e.Encode(root); err != nil {
+		return err
+	}
+
+	return e.Close()
+}
+
+// ToJSON writes the scene to w in the format read by ParseJSON. The values
+// equal to the defaults are left out.
+// It returns an error if the loaders would reject the scene.
+func (s *Scene) ToJSON(w io.Writer) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	var root object
+	if s.Camera != nil {
+		root = append(root, field{"camera", cameraObject(*s.Camera)})
+	}
+	if len(s.Lights) > 0 {
+		lights := make([]any, len(s.Lights))
+		for i, l := range s.Lights {
+			lights[i] = lightObject(l)
+		}
+		root = append(root, field{"lights", lights})
+	}
+	if len(s.Shapes) > 0 {
+		root = append(root, field{"shapes", shapeList(s.Shapes, "type")})
+	}
+
+	var compact bytes.Buffer
+	if err := writeJSON(&compact, root, "$"); err != nil {
+		return err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+
+	_, err := indented.WriteTo(w)
+
+	return err
+}
+
+// WriteFile writes the scene to the file at path, as YAML or JSON following
+// the path extension.
+func (s *Scene) WriteFile(path string) error {
+	var write func(w io.Writer) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		write = s.ToYAML
+	case ".json":
+		write = s.ToJSON
+	default:
+		return ErrUnknownSceneFormat
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// validate returns an error if the loaders would reject the scene, with the
+// JSON path of the invalid value. Scenes built in Go skip the loader checks,
+// so they're checked before being written.
+func (s *Scene) validate() error {
+	if c := s.Camera; c != nil {
+		for _, check := range []struct {
+			key string
+			err error
+		}{
+			{"width", checkSize("width", c.Width)},
+			{"height", checkSize("height", c.Height)},
+			{"field-of-view", checkFieldOfView(c.FieldOfView)},
+		} {
+			if check.err != nil {
+				return jsonErrorf("$.camera."+check.key, "%v", check.err)
+			}
+		}
+	}
+
+	for i, l := range s.Lights {
+		if err := checkColor(l.Intensity); err != nil {
+			return jsonErrorf(fmt.Sprintf("$.lights[%d].intensity", i), "%v", err)
+		}
+	}
+
+	for i, shape := range s.Shapes {
+		if err := validateShape(shape, fmt.Sprintf("$.shapes[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateShape(s Shape, path string) error {
+	if _, ok := shapeKeys[s.Kind]; !ok {
+		return jsonErrorf(path+".type", "unknown shape %q", s.Kind)
+	}
+	if err := validateTransforms(s.Transform, path+".transform"); err != nil {
+		return err
+	}
+	if err := validateMaterial(s.Material, path+".material"); err != nil {
+		return err
+	}
+
+	switch s.Kind {
+	case KindCylinder, KindCone:
+		if err := checkBounds(s.Minimum, s.Maximum); err != nil {
+			return jsonErrorf(path+".min", "%v", err)
+		}
+	case KindGroup:
+		for i, child := range s.Children {
+			if err := validateShape(child, fmt.Sprintf("%s.children[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case KindCSG:
+		if !slices.Contains([]string{OperationUnion, OperationIntersection, OperationDifference}, s.Operation) {
+			return jsonErrorf(path+".operation", "unknown CSG operation %q", s.Operation)
+		}
+		for _, operand := range []struct {
+			key   string
+			shape *Shape
+		}{{"left", s.Left}, {"right", s.Right}} {
+			if operand.shape == nil {
+				return jsonErrorf(path+"."+operand.key, "missing CSG operand")
+			}
+			if err := validateShape(*operand.shape, path+"."+operand.key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateTransforms(ts Transforms, path string) error {
+	for i, t := range ts {
+		args, ok := transformArgs[t.Op]
+		if !ok {
+			return jsonErrorf(fmt.Sprintf("%s[%d][0]", path, i), "unknown transformation %q", t.Op)
+		}
+		if len(t.Args) != args {
+			return jsonErrorf(fmt.Sprintf("%s[%d]", path, i), "%s expects %d arguments but got %d", t.Op, args, len(t.Args))
+		}
+	}
+
+	return nil
+}
+
+func validateMaterial(m Material, path string) error {
+	if err := checkColor(m.Color); err != nil {
+		return jsonErrorf(path+".color", "%v", err)
+	}
+
+	if p := m.Pattern; p != nil {
+		if !slices.Contains([]string{PatternStripes, PatternGradient, PatternRings, PatternCheckers}, p.Type) {
+			return jsonErrorf(path+".pattern.type", "unknown pattern %q", p.Type)
+		}
+		if len(p.Colors) != 2 {
+			return jsonErrorf(path+".pattern.colors", "expected two colors but got %d", len(p.Colors))
+		}
+		for i, c := range p.Colors {
+			if err := checkColor(c); err != nil {
+				return jsonErrorf(fmt.Sprintf("%s.pattern.colors[%d]", path, i), "%v", err)
+			}
+		}
+		if err := validateTransforms(p.Transform, path+".pattern.transform"); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range []struct {
+		key   string
+		value float64
+	}{
+		{"ambient", m.Ambient},
+		{"diffuse", m.Diffuse},
+		{"specular", m.Specular},
+		{"shininess", m.Shininess},
+		{"reflective", m.Reflective},
+		{"transparency", m.Transparency},
+		{"refractive-index", m.RefractiveIndex},
+	} {
+		if err := checkMaterialRange(f.key, f.value); err != nil {
+			return jsonErrorf(path+"."+f.key, "%v", err)
+		}
+	}
+
+	return nil
+}
+
+func cameraObject(c Camera) object {
+	return object{
+		{"width", c.Width},
+		{"height", c.Height},
+		{"field-of-view", c.FieldOfView},
+		{"from", c.From},
+		{"to", c.To},
+		{"up", c.Up},
+	}
+}
+
+func lightObject(l Light) object {
+	return object{
+		{"at", l.At},
+		{"intensity", l.Intensity},
+	}
+}
+
+// shapeObject returns the object of the shape, naming its kind with
+// kindKey.
+func shapeObject(s Shape, kindKey string) object {
+	o := object{{kindKey, s.Kind}}
+	if len(s.Transform) > 0 {
+		o = append(o, field{"transform", transformList(s.Transform)})
+	}
+	if m := materialObject(s.Material); len(m) > 0 {
+		o = append(o, field{"material", m})
+	}
+	if !s.Shadow {
+		o = append(o, field{"shadow", false})
+	}
+
+	switch s.Kind {
+	case KindCylinder, KindCone:
+		if !math.IsInf(s.Minimum, -1) {
+			o = append(o, field{"min", s.Minimum})
+		}
+		if !math.IsInf(s.Maximum, 1) {
+			o = append(o, field{"max", s.Maximum})
+		}
+		if s.Closed {
+			o = append(o, field{"closed", true})
+		}
+	case KindTriangle:
+		o = append(o, field{"p1", s.P1}, field{"p2", s.P2}, field{"p3", s.P3})
+	case KindGroup:
+		if len(s.Children) > 0 {
+			o = append(o, field{"children", shapeList(s.Children, kindKey)})
+		}
+	case KindCSG:
+		o = append(o, field{"operation", s.Operation}, field{"left", shapeObject(*s.Left, kindKey)}, field{"right", shapeObject(*s.Right, kindKey)})
+	case KindOBJ:
+		o = append(o, field{"file", s.File})
+	}
+
+	return o
+}
+
+func shapeList(shapes []Shape, kindKey string) []any {
+	l := make([]any, len(shapes))
+	for i, s := range shapes {
+		l[i] = shapeObject(s, kindKey)
+	}
+
+	return l
+}
+
+// materialObject returns the fields of the material that differ from the
+// default material.
+func materialObject(m Material) object {
+	d := DefaultMaterial()
+
+	var o object
+	if m.Color != d.Color {
+		o = append(o, field{"color", m.Color})
+	}
+	if m.Pattern != nil {
+		p := object{
+			{"type", m.Pattern.Type},
+			{"colors", []any{m.Pattern.Colors[0], m.Pattern.Colors[1]}},
+		}
+		if len(m.Pattern.Transform) > 0 {
+			p = append(p, field{"transform", transformList(m.Pattern.Transform)})
+		}
+		o = append(o, field{"pattern", p})
+	}
+
+	for _, f := range []struct {
+		key   string
+		value float64
+		def   float64
+	}{
+		{"ambient", m.Ambient, d.Ambient},
+		{"diffuse", m.Diffuse, d.Diffuse},
+		{"specular", m.Specular, d.Specular},
+		{"shininess", m.Shininess, d.Shininess},
+		{"reflective", m.Reflective, d.Reflective},
+		{"transparency", m.Transparency, d.Transparency},
+		{"refractive-index", m.RefractiveIndex, d.RefractiveIndex},
+	} {
+		if f.value != f.def {
+			o = append(o, field{f.key, f.value})
+		}
+	}
+
+	return o
+}
+
+func transformList(ts Transforms) []any {
+	l := make([]any, len(ts))
+	for i, t := range ts {
+		item := []any{t.Op}
+		for _, arg := range t.Args {
+			item = append(item, arg)
+		}
+		l[i] = item
+	}
+
+	return l
+}
+
+// yamlNode returns the YAML node of v. Lists of scalars and tuples are
+// written in the flow style, like [ translate, 1, 2, 3 ].
+func yamlNode(v any) *yaml.Node {
+	switch t := v.(type) {
+	case object:
+		n := &yaml.Node{Kind: yaml.MappingNode}
+		for _, f := range t {
+			n.Content = append(n.Content, yamlNode(f.key), yamlNode(f.value))
+		}
+		return n
+	case []any:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range t {
+			c := yamlNode(item)
+			if c.Kind != yaml.ScalarNode {
+				n.Style = 0
+			}
+			n.Content = append(n.Content, c)
+		}
+		return n
+	case feature.Point:
+		return yamlNode([]any{t.X, t.Y, t.Z})
+	case feature.Vector:
+		return yamlNode([]any{t.X, t.Y, t.Z})
+	case feature.Color:
+		return yamlNode([]any{t.R, t.G, t.B})
+	}
+
+	n := &yaml.Node{}
+	// Encoding a scalar can't fail.
+	_ = n.Encode(v)
+
+	return n
+}
+
+// writeJSON writes v as compact JSON to buf. Tuples are written as text,
+// like "point(1, 2, 3)".
+func writeJSON(buf *bytes.Buffer, v any, path string) error {
+	switch t := v.(type) {
+	case object:
+		buf.WriteByte('{')
+		for i, f := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.key)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, f.value, path+"."+f.key); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case float64:
+		if math.IsInf(t, 0) || math.IsNaN(t) {
+			return fmt.Errorf("%w: %s: %g can't be written as JSON", ErrInvalidScene, path, t)
+		}
+	case feature.Point, feature.Vector, feature.Color:
+		text, err := t.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		v = string(text)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Join(ErrInvalidScene, err)
+	}
+	buf.Write(data)
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package scene_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"path/filepath"
+	"ray-tracer/feature"
+	"ray-tracer/scene"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// exportScenes returns the scenes that must survive a round trip.
+func exportScenes(t *testing.T) map[string]*scene.Scene {
+	t.Helper()
+
+	book, err := scene.ParseYAML([]byte(bookScene))
+	if err != nil {
+		t.Fatalf("error parsing the YAML scene: %v", err)
+	}
+	json, err := scene.ParseJSON([]byte(jsonScene))
+	if err != nil {
+		t.Fatalf("error parsing the JSON scene: %v", err)
+	}
+
+	sphere := scene.NewShape(scene.KindSphere)
+	sphere.Transform = scene.Transforms{{Op: scene.OpRotateY, Args: []float64{math.Pi / 3}}}
+	sphere.Material.Color = feature.RGB(0.1+0.2, 1e-300, 1.0/3)
+	sphere.Material.Ambient = 0
+	sphere.Material.RefractiveIndex = 1.52
+	cone := scene.NewShape(scene.KindCone)
+	cone.Maximum = 0
+	mesh := scene.NewShape(scene.KindOBJ)
+	mesh.File = "models/teapot.obj"
+
+	return map[string]*scene.Scene{
+		"book": book,
+		"json": json,
+		"floats": {
+			Lights: []scene.Light{{At: feature.Pt(-10, 1e6, 0.000123), Intensity: feature.RGB(1, 1, 1)}},
+			Shapes: []scene.Shape{sphere, cone, mesh},
+		},
+		"empty": {},
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	formats := []struct {
+		name  string
+		write func(s *scene.Scene) ([]byte, error)
+		parse func(data []byte) (*scene.Scene, error)
+	}{
+		{
+			name: "yaml",
+			write: func(s *scene.Scene) ([]byte, error) {
+				var buf bytes.Buffer
+				err := s.ToYAML(&buf)
+				return buf.Bytes(), err
+			},
+			parse: scene.ParseYAML,
+		},
+		{
+			name: "json",
+			write: func(s *scene.Scene) ([]byte, error) {
+				var buf bytes.Buffer
+				err := s.ToJSON(&buf)
+				return buf.Bytes(), err
+			},
+			parse: scene.ParseJSON,
+		},
+		{
+			name: "json to yaml",
+			write: func(s *scene.Scene) ([]byte, error) {
+				var buf bytes.Buffer
+				if err := s.ToJSON(&buf); err != nil {
+					return nil, err
+				}
+				s, err := scene.ParseJSON(buf.Bytes())
+				if err != nil {
+					return nil, err
+				}
+				buf.Reset()
+				err = s.ToYAML(&buf)
+				return buf.Bytes(), err
+			},
+			parse: scene.ParseYAML,
+		},
+		{
+			name: "yaml to json",
+			write: func(s *scene.Scene) ([]byte, error) {
+				var buf bytes.Buffer
+				if err := s.ToYAML(&buf); err != nil {
+					return nil, err
+				}
+				s, err := scene.ParseYAML(buf.Bytes())
+				if err != nil {
+					return nil, err
+				}
+				buf.Reset()
+				err = s.ToJSON(&buf)
+				return buf.Bytes(), err
+			},
+			parse: scene.ParseJSON,
+		},
+	}
+
+	for name, want := range exportScenes(t) {
+		for _, format := range formats {
+			t.Run(name+"/"+format.name, func(t *testing.T) {
+				data, err := format.write(want)
+				if err != nil {
+					t.Fatalf("error writing the scene: %v", err)
+				}
+
+				got, err := format.parse(data)
+				if err != nil {
+					t.Fatalf("error parsing the written scene: %v\n%s", err, data)
+				}
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("wants\n%+v\nand got\n%+v\nfrom\n%s", want, got, data)
+				}
+			})
+		}
+	}
+}
+
+func TestExportInvalid(t *testing.T) {
+	stripes := scene.NewShape(scene.KindSphere)
+	stripes.Material.Pattern = &scene.Pattern{Type: scene.PatternStripes, Colors: []feature.Color{feature.RGB(1, 1, 1)}}
+	csg := scene.NewShape(scene.KindCSG)
+	csg.Operation, csg.Right = scene.OperationUnion, &stripes
+	translated := scene.NewShape(scene.KindCube)
+	translated.Transform = scene.Transforms{{Op: scene.OpTranslate, Args: []float64{1, 2}}}
+	group := scene.NewShape(scene.KindGroup)
+	group.Children = []scene.Shape{translated}
+	camera := &scene.Camera{Width: 10, Height: 10, FieldOfView: 3.5, Up: feature.Vec(0, 1, 0)}
+
+	tests := []struct {
+		name  string
+		scene *scene.Scene
+		err   string
+	}{
+		{
+			name:  "pattern with one color",
+			scene: &scene.Scene{Shapes: []scene.Shape{stripes}},
+			err:   "$.shapes[0].material.pattern.colors: expected two colors but got 1",
+		},
+		{
+			name:  "CSG without left",
+			scene: &scene.Scene{Shapes: []scene.Shape{csg}},
+			err:   "$.shapes[0].left: missing CSG operand",
+		},
+		{
+			name:  "wrong number of arguments",
+			scene: &scene.Scene{Shapes: []scene.Shape{group}},
+			err:   "$.shapes[0].children[0].transform[0]: translate expects 3 arguments but got 2",
+		},
+		{
+			name:  "field of view out of range",
+			scene: &scene.Scene{Camera: camera},
+			err:   "$.camera.field-of-view: field-of-view must be between 0 and π radians but got 3.5",
+		},
+		{
+			name:  "negative color",
+			scene: &scene.Scene{Lights: []scene.Light{{Intensity: feature.RGB(-1, 1, 1)}}},
+			err:   "$.lights[0].intensity: r must be at least 0 but got -1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, write := range map[string]func(w io.Writer) error{"yaml": test.scene.ToYAML, "json": test.scene.ToJSON} {
+				err := write(io.Discard)
+
+				if !errors.Is(err, scene.ErrInvalidScene) || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("%q as %s: got error %v, expected an invalid scene error with %q", test.name, name, err, test.err)
+				}
+			}
+		})
+	}
+}
+
+func TestToJSONInfinity(t *testing.T) {
+	cylinder := scene.NewShape(scene.KindCylinder)
+	cylinder.Minimum = math.Inf(1)
+	s := &scene.Scene{Shapes: []scene.Shape{cylinder}}
+
+	var buf bytes.Buffer
+	if err := s.ToJSON(&buf); !errors.Is(err, scene.ErrInvalidScene) {
+		t.Errorf("got error %v, expected error %v", err, scene.ErrInvalidScene)
+	}
+	if err := s.ToYAML(&buf); err != nil {
+		t.Errorf("error writing the scene as YAML: %v", err)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	want, err := scene.ParseYAML([]byte(bookScene))
+	if err != nil {
+		t.Fatalf("error parsing the scene: %v", err)
+	}
+	dir := t.TempDir()
+
+	tests := []struct {
+		file string
+		err  error
+	}{
+		{file: "scene.yaml"},
+		{file: "scene.json"},
+		{file: "scene.txt", err: scene.ErrUnknownSceneFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			err := want.WriteFile(path)
+
+			if !errors.Is(err, test.err) {
+				t.Fatalf("%q: got error %v, expected error %v", test.file, err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := scene.Load(path)
+			if err != nil {
+				t.Fatalf("%q: error loading the scene: %v", test.file, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%q: wants\n%+v\nand got\n%+v", test.file, want, got)
+			}
+		})
+	}
+}
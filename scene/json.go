@@ -0,0 +1,615 @@
+package scene
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"ray-tracer/feature"
+	"slices"
+	"strings"
+)
+
+// JSONSchema is the JSON Schema of the JSON scene format.
+//
+//go:embed scene.schema.json
+var JSONSchema []byte
+
+// LoadJSON reads a Scene from the JSON file at path.
+func LoadJSON(path string) (*Scene, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := ParseJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// ParseJSON reads a Scene from the JSON format described by JSONSchema: an
+// object with the camera, the lights and the shapes, using the keys of the
+// YAML format, where each shape names its kind with type. Points, vectors and colors are tuple objects, like
+// {"type": "point", "x": 1, "y": 2, "z": 3}, or tuple text, like
+// "point(1, 2, 3)", so a vector can't be used for a point.
+// The errors wrap ErrInvalidScene and include the JSON path of the problem,
+// like $.shapes[0].material.ambient.
+func ParseJSON(data []byte) (*Scene, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var root any
+	if err := d.Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidScene, err)
+	}
+	if _, err := d.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unexpected data after the scene", ErrInvalidScene)
+	}
+
+	fields, err := jsonFields(root, "$", "$schema", "camera", "lights", "shapes")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scene{}
+	if v, ok := fields["camera"]; ok {
+		c, err := jsonCamera(v, "$.camera")
+		if err != nil {
+			return nil, err
+		}
+		s.Camera = &c
+	}
+
+	if v, ok := fields["lights"]; ok {
+		items, err := jsonArray(v, "$.lights")
+		if err != nil {
+			return nil, err
+		}
+		for i, item := range items {
+			l, err := jsonLight(item, fmt.Sprintf("$.lights[%d]", i))
+			if err != nil {
+				return nil, err
+			}
+			s.Lights = append(s.Lights, l)
+		}
+	}
+
+	if v, ok := fields["shapes"]; ok {
+		if s.Shapes, err = jsonShapes(v, "$.shapes"); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func jsonCamera(v any, path string) (Camera, error) {
+	var c Camera
+
+	fields, err := jsonFields(v, path, cameraKeys...)
+	if err != nil {
+		return c, err
+	}
+	if err := jsonRequired(fields, path, cameraKeys...); err != nil {
+		return c, err
+	}
+
+	if c.Width, err = jsonInt(fields["width"], path+".width"); err != nil {
+		return c, err
+	}
+	if err := checkSize("width", c.Width); err != nil {
+		return c, jsonErrorf(path+".width", "%v", err)
+	}
+	if c.Height, err = jsonInt(fields["height"], path+".height"); err != nil {
+		return c, err
+	}
+	if err := checkSize("height", c.Height); err != nil {
+		return c, jsonErrorf(path+".height", "%v", err)
+	}
+	if c.FieldOfView, err = jsonNumber(fields["field-of-view"], path+".field-of-view"); err != nil {
+		return c, err
+	}
+	if err := checkFieldOfView(c.FieldOfView); err != nil {
+		return c, jsonErrorf(path+".field-of-view", "%v", err)
+	}
+	if c.From, err = jsonPoint(fields["from"], path+".from"); err != nil {
+		return c, err
+	}
+	if c.To, err = jsonPoint(fields["to"], path+".to"); err != nil {
+		return c, err
+	}
+	if c.Up, err = jsonVector(fields["up"], path+".up"); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+func jsonLight(v any, path string) (Light, error) {
+	l := Light{Intensity: feature.RGB(1, 1, 1)}
+
+	fields, err := jsonFields(v, path, lightKeys...)
+	if err != nil {
+		return l, err
+	}
+	if err := jsonRequired(fields, path, "at"); err != nil {
+		return l, err
+	}
+
+	if l.At, err = jsonPoint(fields["at"], path+".at"); err != nil {
+		return l, err
+	}
+	if v, ok := fields["intensity"]; ok {
+		if l.Intensity, err = jsonColor(v, path+".intensity"); err != nil {
+			return l, err
+		}
+	}
+
+	return l, nil
+}
+
+func jsonShapes(v any, path string) ([]Shape, error) {
+	items, err := jsonArray(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	shapes := make([]Shape, 0, len(items))
+	for i, item := range items {
+		s, err := jsonShape(item, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		shapes = append(shapes, s)
+	}
+
+	return shapes, nil
+}
+
+func jsonShape(v any, path string) (Shape, error) {
+	fields, err := jsonFields(v, path)
+	if err != nil {
+		return Shape{}, err
+	}
+	if err := jsonRequired(fields, path, "type"); err != nil {
+		return Shape{}, err
+	}
+	kind, err := jsonString(fields["type"], path+".type")
+	if err != nil {
+		return Shape{}, err
+	}
+	keys, ok := shapeKeys[kind]
+	if !ok {
+		return Shape{}, jsonErrorf(path+".type", "unknown shape %q", kind)
+	}
+	if _, err := jsonFields(v, path, append(append([]string{"type"}, shapeCommonKeys...), keys...)...); err != nil {
+		return Shape{}, err
+	}
+
+	s := NewShape(kind)
+	if v, ok := fields["transform"]; ok {
+		if s.Transform, err = jsonTransforms(v, path+".transform"); err != nil {
+			return s, err
+		}
+	}
+	if v, ok := fields["material"]; ok {
+		if s.Material, err = jsonMaterial(v, path+".material"); err != nil {
+			return s, err
+		}
+	}
+	if v, ok := fields["shadow"]; ok {
+		if s.Shadow, err = jsonBool(v, path+".shadow"); err != nil {
+			return s, err
+		}
+	}
+
+	switch kind {
+	case KindCylinder, KindCone:
+		if v, ok := fields["min"]; ok {
+			if s.Minimum, err = jsonNumber(v, path+".min"); err != nil {
+				return s, err
+			}
+		}
+		if v, ok := fields["max"]; ok {
+			if s.Maximum, err = jsonNumber(v, path+".max"); err != nil {
+				return s, err
+			}
+		}
+		if err := checkBounds(s.Minimum, s.Maximum); err != nil {
+			return s, jsonErrorf(path+".min", "%v", err)
+		}
+		if v, ok := fields["closed"]; ok {
+			if s.Closed, err = jsonBool(v, path+".closed"); err != nil {
+				return s, err
+			}
+		}
+	case KindTriangle:
+		if err := jsonRequired(fields, path, "p1", "p2", "p3"); err != nil {
+			return s, err
+		}
+		for _, c := range []struct {
+			key string
+			p   *feature.Point
+		}{{"p1", &s.P1}, {"p2", &s.P2}, {"p3", &s.P3}} {
+			if *c.p, err = jsonPoint(fields[c.key], path+"."+c.key); err != nil {
+				return s, err
+			}
+		}
+	case KindGroup:
+		if v, ok := fields["children"]; ok {
+			if s.Children, err = jsonShapes(v, path+".children"); err != nil {
+				return s, err
+			}
+		}
+	case KindCSG:
+		if err := jsonRequired(fields, path, "operation", "left", "right"); err != nil {
+			return s, err
+		}
+		if s.Operation, err = jsonString(fields["operation"], path+".operation"); err != nil {
+			return s, err
+		}
+		if !slices.Contains([]string{OperationUnion, OperationIntersection, OperationDifference}, s.Operation) {
+			return s, jsonErrorf(path+".operation", "unknown CSG operation %q", s.Operation)
+		}
+		left, err := jsonShape(fields["left"], path+".left")
+		if err != nil {
+			return s, err
+		}
+		right, err := jsonShape(fields["right"], path+".right")
+		if err != nil {
+			return s, err
+		}
+		s.Left, s.Right = &left, &right
+	case KindOBJ:
+		if err := jsonRequired(fields, path, "file"); err != nil {
+			return s, err
+		}
+		if s.File, err = jsonString(fields["file"], path+".file"); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
+
+// jsonTransforms reads a list of transformations, where each item is an
+// operation with its arguments, like ["translate", 1, 2, 3].
+func jsonTransforms(v any, path string) (Transforms, error) {
+	items, err := jsonArray(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := make(Transforms, 0, len(items))
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		values, ok := item.([]any)
+		if !ok || len(values) == 0 {
+			return nil, jsonErrorf(itemPath, `expected a transformation like ["translate", 1, 2, 3] but got %s`, jsonType(item))
+		}
+
+		op, err := jsonString(values[0], itemPath+"[0]")
+		if err != nil {
+			return nil, err
+		}
+		args, ok := transformArgs[op]
+		if !ok {
+			return nil, jsonErrorf(itemPath+"[0]", "unknown transformation %q", op)
+		}
+		if len(values)-1 != args {
+			return nil, jsonErrorf(itemPath, "%s expects %d arguments but got %d", op, args, len(values)-1)
+		}
+
+		t := Transform{Op: op, Args: make([]float64, args)}
+		for j, arg := range values[1:] {
+			if t.Args[j], err = jsonNumber(arg, fmt.Sprintf("%s[%d]", itemPath, j+1)); err != nil {
+				return nil, err
+			}
+		}
+		ts = append(ts, t)
+	}
+
+	return ts, nil
+}
+
+// jsonMaterial reads a material object over the default material.
+func jsonMaterial(v any, path string) (Material, error) {
+	m := DefaultMaterial()
+
+	fields, err := jsonFields(v, path, materialKeys...)
+	if err != nil {
+		return m, err
+	}
+
+	if v, ok := fields["color"]; ok {
+		if m.Color, err = jsonColor(v, path+".color"); err != nil {
+			return m, err
+		}
+	}
+	if v, ok := fields["pattern"]; ok {
+		pattern, err := jsonPattern(v, path+".pattern")
+		if err != nil {
+			return m, err
+		}
+		m.Pattern = &pattern
+	}
+
+	for _, f := range []struct {
+		key   string
+		value *float64
+	}{
+		{"ambient", &m.Ambient},
+		{"diffuse", &m.Diffuse},
+		{"specular", &m.Specular},
+		{"shininess", &m.Shininess},
+		{"reflective", &m.Reflective},
+		{"transparency", &m.Transparency},
+		{"refractive-index", &m.RefractiveIndex},
+	} {
+		if v, ok := fields[f.key]; ok {
+			if *f.value, err = jsonNumber(v, path+"."+f.key); err != nil {
+				return m, err
+			}
+			if err := checkMaterialRange(f.key, *f.value); err != nil {
+				return m, jsonErrorf(path+"."+f.key, "%v", err)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func jsonPattern(v any, path string) (Pattern, error) {
+	var pattern Pattern
+
+	fields, err := jsonFields(v, path, patternKeys...)
+	if err != nil {
+		return pattern, err
+	}
+	if err := jsonRequired(fields, path, "type", "colors"); err != nil {
+		return pattern, err
+	}
+
+	if pattern.Type, err = jsonString(fields["type"], path+".type"); err != nil {
+		return pattern, err
+	}
+	if !slices.Contains([]string{PatternStripes, PatternGradient, PatternRings, PatternCheckers}, pattern.Type) {
+		return pattern, jsonErrorf(path+".type", "unknown pattern %q", pattern.Type)
+	}
+
+	colors, err := jsonArray(fields["colors"], path+".colors")
+	if err != nil {
+		return pattern, err
+	}
+	if len(colors) != 2 {
+		return pattern, jsonErrorf(path+".colors", "expected two colors but got %d", len(colors))
+	}
+	for i, c := range colors {
+		color, err := jsonColor(c, fmt.Sprintf("%s.colors[%d]", path, i))
+		if err != nil {
+			return pattern, err
+		}
+		pattern.Colors = append(pattern.Colors, color)
+	}
+
+	if v, ok := fields["transform"]; ok {
+		if pattern.Transform, err = jsonTransforms(v, path+".transform"); err != nil {
+			return pattern, err
+		}
+	}
+
+	return pattern, nil
+}
+
+// jsonErrorf returns an ErrInvalidScene error at the JSON path.
+func jsonErrorf(path, format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidScene, path, fmt.Sprintf(format, args...))
+}
+
+// jsonType describes the JSON type of v for the errors.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	case string:
+		return "a string"
+	case []any:
+		return "an array"
+	}
+
+	return "an object"
+}
+
+// jsonFields returns the fields of the object v. When allowed isn't empty,
+// the object can't have other keys.
+func jsonFields(v any, path string, allowed ...string) (map[string]any, error) {
+	fields, ok := v.(map[string]any)
+	if !ok {
+		return nil, jsonErrorf(path, "expected an object but got %s", jsonType(v))
+	}
+
+	if len(allowed) > 0 {
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			if !slices.Contains(allowed, key) {
+				return nil, jsonErrorf(path+"."+key, "unknown key, expected one of %s", strings.Join(allowed, ", "))
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// jsonRequired checks that the object at path has the required keys.
+func jsonRequired(fields map[string]any, path string, required ...string) error {
+	for _, key := range required {
+		if _, ok := fields[key]; !ok {
+			return jsonErrorf(path, "missing key %q", key)
+		}
+	}
+
+	return nil
+}
+
+func jsonArray(v any, path string) ([]any, error) {
+	a, ok := v.([]any)
+	if !ok {
+		return nil, jsonErrorf(path, "expected an array but got %s", jsonType(v))
+	}
+
+	return a, nil
+}
+
+func jsonString(v any, path string) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", jsonErrorf(path, "expected a string but got %s", jsonType(v))
+	}
+
+	return s, nil
+}
+
+func jsonNumber(v any, path string) (float64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, jsonErrorf(path, "expected a number but got %s", jsonType(v))
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return 0, jsonErrorf(path, "invalid number %s", n)
+	}
+
+	return f, nil
+}
+
+func jsonInt(v any, path string) (int, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, jsonErrorf(path, "expected an integer but got %s", jsonType(v))
+	}
+
+	i, err := n.Int64()
+	if err != nil {
+		return 0, jsonErrorf(path, "expected an integer but got %s", n)
+	}
+
+	return int(i), nil
+}
+
+func jsonBool(v any, path string) (bool, error) {
+	b, ok := v.(bool)
+	if !ok {
+		return false, jsonErrorf(path, "expected a boolean but got %s", jsonType(v))
+	}
+
+	return b, nil
+}
+
+// jsonTuple reads a tuple of kind (point, vector or color), either as an
+// object or as text, returning its three values.
+func jsonTuple(v any, path, kind string) ([3]float64, error) {
+	var values [3]float64
+
+	names := [3]string{"x", "y", "z"}
+	if kind == "color" {
+		names = [3]string{"r", "g", "b"}
+	}
+	example := fmt.Sprintf(`{"type": %q, %q: 0, %q: 0, %q: 0}`, kind, names[0], names[1], names[2])
+
+	switch t := v.(type) {
+	case string:
+		got, _, _ := strings.Cut(t, "(")
+		if got = strings.TrimSpace(got); got != kind && slices.Contains([]string{"point", "vector", "color", "tuple"}, got) {
+			return values, jsonErrorf(path, "expected a %s but got a %s", kind, got)
+		}
+
+		var tuple feature.Tuple
+		switch kind {
+		case "point":
+			var p feature.Point
+			if err := p.UnmarshalText([]byte(t)); err != nil {
+				return values, jsonErrorf(path, "expected a point like %q but got %q", "point(0, 0, 0)", t)
+			}
+			tuple = p.Tuple()
+		case "vector":
+			var v feature.Vector
+			if err := v.UnmarshalText([]byte(t)); err != nil {
+				return values, jsonErrorf(path, "expected a vector like %q but got %q", "vector(0, 0, 0)", t)
+			}
+			tuple = v.Tuple()
+		case "color":
+			var c feature.Color
+			if err := c.UnmarshalText([]byte(t)); err != nil {
+				return values, jsonErrorf(path, "expected a color like %q but got %q", "color(0, 0, 0)", t)
+			}
+			tuple = c.Tuple()
+		}
+
+		return [3]float64{tuple.X, tuple.Y, tuple.Z}, nil
+	case map[string]any:
+		got, err := jsonString(t["type"], path+".type")
+		if err != nil {
+			return values, err
+		}
+		if got != kind {
+			return values, jsonErrorf(path, "expected a %s but got a %s", kind, got)
+		}
+		if _, err := jsonFields(t, path, "type", names[0], names[1], names[2]); err != nil {
+			return values, err
+		}
+		if err := jsonRequired(t, path, names[:]...); err != nil {
+			return values, err
+		}
+		for i, name := range names {
+			if values[i], err = jsonNumber(t[name], path+"."+name); err != nil {
+				return values, err
+			}
+		}
+
+		return values, nil
+	}
+
+	return values, jsonErrorf(path, "expected a %s like %s but got %s", kind, example, jsonType(v))
+}
+
+func jsonPoint(v any, path string) (feature.Point, error) {
+	t, err := jsonTuple(v, path, "point")
+
+	return feature.Pt(t[0], t[1], t[2]), err
+}
+
+func jsonVector(v any, path string) (feature.Vector, error) {
+	t, err := jsonTuple(v, path, "vector")
+
+	return feature.Vec(t[0], t[1], t[2]), err
+}
+
+// jsonColor reads a color, which can't have negative channels.
+func jsonColor(v any, path string) (feature.Color, error) {
+	t, err := jsonTuple(v, path, "color")
+	if err != nil {
+		return feature.Color{}, err
+	}
+	c := feature.RGB(t[0], t[1], t[2])
+	if err := checkColor(c); err != nil {
+		return c, jsonErrorf(path, "%v", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,369 @@
+package scene_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"ray-tracer/scene"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const jsonScene = `{
+  "$schema": "scene.schema.json",
+  "camera": {
+    "width": 100,
+    "height": 50,
+    "field-of-view": 0.785,
+    "from": {"type": "point", "x": -6, "y": 6, "z": -10},
+    "to": "point(6, 0, 6)",
+    "up": {"type": "vector", "x": -0.45, "y": 1, "z": 0}
+  },
+  "lights": [
+    {"at": {"type": "point", "x": 50, "y": 100, "z": -50}, "intensity": {"type": "color", "r": 1, "g": 1, "b": 1}},
+    {"at": "point(-400, 50, -10)", "intensity": "color(0.2, 0.2, 0.2)"}
+  ],
+  "shapes": [
+    {
+      "type": "plane",
+      "material": {"color": "color(1, 1, 1)", "diffuse": 0.7, "specular": 0, "reflective": 0.1},
+      "transform": [["rotate-x", 1.5707963267948966], ["translate", 0, 0, 500]]
+    },
+    {
+      "type": "group",
+      "children": [
+        {
+          "type": "cylinder",
+          "min": 0,
+          "max": 1,
+          "closed": true,
+          "shadow": false,
+          "material": {
+            "pattern": {
+              "type": "stripes",
+              "colors": ["color(1, 1, 1)", "color(0, 0, 0)"],
+              "transform": [["scale", 0.25, 0.25, 0.25]]
+            }
+          }
+        },
+        {"type": "triangle", "p1": "point(0, 1, 0)", "p2": "point(-1, 0, 0)", "p3": "point(1, 0, 0)"}
+      ]
+    },
+    {"type": "csg", "operation": "difference", "left": {"type": "sphere"}, "right": {"type": "cone"}},
+    {"type": "obj", "file": "teapot.obj"}
+  ]
+}`
+
+func TestParseJSON(t *testing.T) {
+	got, err := scene.ParseJSON([]byte(jsonScene))
+	if err != nil {
+		t.Fatalf("error parsing the scene: %v", err)
+	}
+
+	plane := scene.NewShape(scene.KindPlane)
+	plane.Material.Diffuse = 0.7
+	plane.Material.Specular = 0
+	plane.Material.Reflective = 0.1
+	plane.Transform = scene.Transforms{
+		{Op: scene.OpRotateX, Args: []float64{math.Pi / 2}},
+		{Op: scene.OpTranslate, Args: []float64{0, 0, 500}},
+	}
+
+	cylinder := scene.NewShape(scene.KindCylinder)
+	cylinder.Minimum, cylinder.Maximum, cylinder.Closed, cylinder.Shadow = 0, 1, true, false
+	cylinder.Material.Pattern = &scene.Pattern{
+		Type:      scene.PatternStripes,
+		Colors:    []feature.Color{feature.RGB(1, 1, 1), feature.RGB(0, 0, 0)},
+		Transform: scene.Transforms{{Op: scene.OpScale, Args: []float64{0.25, 0.25, 0.25}}},
+	}
+	triangle := scene.NewShape(scene.KindTriangle)
+	triangle.P1, triangle.P2, triangle.P3 = feature.Pt(0, 1, 0), feature.Pt(-1, 0, 0), feature.Pt(1, 0, 0)
+	group := scene.NewShape(scene.KindGroup)
+	group.Children = []scene.Shape{cylinder, triangle}
+
+	sphere, cone := scene.NewShape(scene.KindSphere), scene.NewShape(scene.KindCone)
+	csg := scene.NewShape(scene.KindCSG)
+	csg.Operation, csg.Left, csg.Right = scene.OperationDifference, &sphere, &cone
+
+	obj := scene.NewShape(scene.KindOBJ)
+	obj.File = "teapot.obj"
+
+	want := &scene.Scene{
+		Camera: &scene.Camera{
+			Width:       100,
+			Height:      50,
+			FieldOfView: 0.785,
+			From:        feature.Pt(-6, 6, -10),
+			To:          feature.Pt(6, 0, 6),
+			Up:          feature.Vec(-0.45, 1, 0),
+		},
+		Lights: []scene.Light{
+			{At: feature.Pt(50, 100, -50), Intensity: feature.RGB(1, 1, 1)},
+			{At: feature.Pt(-400, 50, -10), Intensity: feature.RGB(0.2, 0.2, 0.2)},
+		},
+		Shapes: []scene.Shape{plane, group, csg, obj},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wants\n%+v\nand got\n%+v", want, got)
+	}
+}
+
+func TestParseJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		err  string
+	}{
+		{
+			name: "invalid syntax",
+			json: `{"shapes": [}`,
+			err:  "invalid character",
+		},
+		{
+			name: "trailing data",
+			json: `{} {}`,
+			err:  "unexpected data after the scene",
+		},
+		{
+			name: "not an object",
+			json: `[]`,
+			err:  "$: expected an object but got an array",
+		},
+		{
+			name: "unknown top level key",
+			json: `{"world": {}}`,
+			err:  "$.world: unknown key",
+		},
+		{
+			name: "unknown shape key",
+			json: `{"shapes": [{"type": "sphere"}, {"type": "sphere", "radius": 2}]}`,
+			err:  "$.shapes[1].radius: unknown key",
+		},
+		{
+			name: "key of another kind",
+			json: `{"shapes": [{"type": "cube", "closed": true}]}`,
+			err:  "$.shapes[0].closed: unknown key",
+		},
+		{
+			name: "unknown shape",
+			json: `{"shapes": [{"type": "teapot"}]}`,
+			err:  `$.shapes[0].type: unknown shape "teapot"`,
+		},
+		{
+			name: "missing shape type",
+			json: `{"shapes": [{"shadow": true}]}`,
+			err:  `$.shapes[0]: missing key "type"`,
+		},
+		{
+			name: "number as a string",
+			json: `{"camera": {"width": "100", "height": 50, "field-of-view": 1, "from": "point(0, 0, 0)", "to": "point(0, 0, 1)", "up": "vector(0, 1, 0)"}}`,
+			err:  "$.camera.width: expected an integer but got a string",
+		},
+		{
+			name: "fractional size",
+			json: `{"camera": {"width": 100.5, "height": 50, "field-of-view": 1, "from": "point(0, 0, 0)", "to": "point(0, 0, 1)", "up": "vector(0, 1, 0)"}}`,
+			err:  "$.camera.width: expected an integer but got 100.5",
+		},
+		{
+			name: "field of view out of range",
+			json: `{"camera": {"width": 100, "height": 50, "field-of-view": 4, "from": "point(0, 0, 0)", "to": "point(0, 0, 1)", "up": "vector(0, 1, 0)"}}`,
+			err:  "$.camera.field-of-view: field-of-view must be between 0 and π radians but got 4",
+		},
+		{
+			name: "vector for a point",
+			json: `{"camera": {"width": 100, "height": 50, "field-of-view": 1, "from": {"type": "vector", "x": 0, "y": 0, "z": 0}, "to": "point(0, 0, 1)", "up": "vector(0, 1, 0)"}}`,
+			err:  "$.camera.from: expected a point but got a vector",
+		},
+		{
+			name: "point text for a vector",
+			json: `{"camera": {"width": 100, "height": 50, "field-of-view": 1, "from": "point(0, 0, 0)", "to": "point(0, 0, 1)", "up": "point(0, 1, 0)"}}`,
+			err:  "$.camera.up: expected a vector but got a point",
+		},
+		{
+			name: "array for a point",
+			json: `{"lights": [{"at": [0, 0, 0]}]}`,
+			err:  `$.lights[0].at: expected a point like {"type": "point", "x": 0, "y": 0, "z": 0} but got an array`,
+		},
+		{
+			name: "invalid point text",
+			json: `{"lights": [{"at": "point(0, 0)"}]}`,
+			err:  `$.lights[0].at: expected a point like "point(0, 0, 0)" but got "point(0, 0)"`,
+		},
+		{
+			name: "missing coordinate",
+			json: `{"lights": [{"at": {"type": "point", "x": 0, "y": 0}}]}`,
+			err:  `$.lights[0].at: missing key "z"`,
+		},
+		{
+			name: "coordinate type",
+			json: `{"lights": [{"at": {"type": "point", "x": 0, "y": null, "z": 0}}]}`,
+			err:  "$.lights[0].at.y: expected a number but got null",
+		},
+		{
+			name: "negative color",
+			json: `{"lights": [{"at": "point(0, 0, 0)", "intensity": {"type": "color", "r": 1, "g": -1, "b": 1}}]}`,
+			err:  "$.lights[0].intensity: g must be at least 0 but got -1",
+		},
+		{
+			name: "material value out of range",
+			json: `{"shapes": [{"type": "group", "children": [{"type": "sphere", "material": {"transparency": 1.5}}]}]}`,
+			err:  "$.shapes[0].children[0].material.transparency: transparency must be between 0 and 1 but got 1.5",
+		},
+		{
+			name: "refractive index out of range",
+			json: `{"shapes": [{"type": "sphere", "material": {"refractive-index": 0.5}}]}`,
+			err:  "$.shapes[0].material.refractive-index: refractive-index must be at least 1 but got 0.5",
+		},
+		{
+			name: "unknown material key",
+			json: `{"shapes": [{"type": "sphere", "material": {"roughness": 0.5}}]}`,
+			err:  "$.shapes[0].material.roughness: unknown key",
+		},
+		{
+			name: "unknown transformation",
+			json: `{"shapes": [{"type": "sphere", "transform": [["scale", 1, 1, 1], ["spin", 1]]}]}`,
+			err:  `$.shapes[0].transform[1][0]: unknown transformation "spin"`,
+		},
+		{
+			name: "transformation argument",
+			json: `{"shapes": [{"type": "sphere", "transform": [["translate", 1, "2", 3]]}]}`,
+			err:  "$.shapes[0].transform[0][2]: expected a number but got a string",
+		},
+		{
+			name: "wrong number of arguments",
+			json: `{"shapes": [{"type": "sphere", "transform": [["rotate-x"]]}]}`,
+			err:  "$.shapes[0].transform[0]: rotate-x expects 1 arguments but got 0",
+		},
+		{
+			name: "pattern colors",
+			json: `{"shapes": [{"type": "sphere", "material": {"pattern": {"type": "rings", "colors": ["color(1, 1, 1)"]}}}]}`,
+			err:  "$.shapes[0].material.pattern.colors: expected two colors but got 1",
+		},
+		{
+			name: "cylinder bounds",
+			json: `{"shapes": [{"type": "cylinder", "min": 2, "max": 1}]}`,
+			err:  "$.shapes[0].min: min must be at most max but got 2 and 1",
+		},
+		{
+			name: "CSG side",
+			json: `{"shapes": [{"type": "csg", "operation": "union", "left": {"type": "sphere"}, "right": {"type": "cube", "shadow": 1}}]}`,
+			err:  "$.shapes[0].right.shadow: expected a boolean but got a number",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := scene.ParseJSON([]byte(test.json))
+
+			if !errors.Is(err, scene.ErrInvalidScene) || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: got error %v, expected an invalid scene error with %q", test.name, err, test.err)
+			}
+		})
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	var schema struct {
+		Defs map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(scene.JSONSchema, &schema); err != nil {
+		t.Fatalf("error reading the schema: %v", err)
+	}
+
+	// The schema lists every key the parser accepts.
+	for _, def := range []string{"point", "vector", "color", "camera", "light", "transforms", "pattern", "material", "shape"} {
+		if _, ok := schema.Defs[def]; !ok {
+			t.Errorf("wants the definition %q in the schema", def)
+		}
+	}
+	for _, key := range []string{"field-of-view", "refractive-index", "prefixItems", "closed", "children", "operation", "file"} {
+		if !strings.Contains(string(scene.JSONSchema), `"`+key+`"`) {
+			t.Errorf("wants the key %q in the schema", key)
+		}
+	}
+
+	// Both parsers accept the material values in the schema bounds and
+	// reject the values beyond them.
+	var material struct {
+		Properties map[string]struct {
+			Type    string   `json:"type"`
+			Minimum *float64 `json:"minimum"`
+			Maximum *float64 `json:"maximum"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema.Defs["material"], &material); err != nil {
+		t.Fatalf("error reading the material schema: %v", err)
+	}
+	parse := func(key string, v float64) []error {
+		_, jsonErr := scene.ParseJSON([]byte(fmt.Sprintf(`{"shapes": [{"type": "sphere", "material": {%q: %g}}]}`, key, v)))
+		_, yamlErr := scene.ParseYAML([]byte(fmt.Sprintf("- add: sphere\n  material:\n    %s: %g", key, v)))
+		return []error{jsonErr, yamlErr}
+	}
+	for key, p := range material.Properties {
+		if p.Type != "number" {
+			continue
+		}
+
+		type check struct {
+			value float64
+			valid bool
+		}
+		checks := []check{{-1e300, p.Minimum == nil}, {1e300, p.Maximum == nil}}
+		if p.Minimum != nil {
+			checks = append(checks, check{*p.Minimum, true}, check{*p.Minimum - 0.5, false})
+		}
+		if p.Maximum != nil {
+			checks = append(checks, check{*p.Maximum, true}, check{*p.Maximum + 0.5, false})
+		}
+
+		for _, c := range checks {
+			for i, err := range parse(key, c.value) {
+				if (err == nil) != c.valid {
+					t.Errorf("%s %g in %s: wants valid %v and got error %v", key, c.value, [2]string{"JSON", "YAML"}[i], c.valid, err)
+				}
+			}
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"scene.json": `{"shapes": [{"type": "sphere"}]}`,
+		"scene.yml":  "- add: sphere\n",
+		"scene.txt":  "sphere",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatalf("error writing %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		file string
+		err  error
+	}{
+		{file: "scene.json"},
+		{file: "scene.yml"},
+		{file: "scene.txt", err: scene.ErrUnknownSceneFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			got, err := scene.Load(filepath.Join(dir, test.file))
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("%q: got error %v, expected error %v", test.file, err, test.err)
+			}
+			if err == nil && len(got.Shapes) != 1 {
+				t.Errorf("%q: wants a shape and got %+v", test.file, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package scene
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"ray-tracer/feature"
+	"strings"
+)
+
+// Transform operations.
+const (
+	OpTranslate = "translate"
+	OpScale     = "scale"
+	OpRotateX   = "rotate-x"
+	OpRotateY   = "rotate-y"
+	OpRotateZ   = "rotate-z"
+	OpShear     = "shear"
+)
+
+// Shape kinds.
+const (
+	KindSphere   = "sphere"
+	KindPlane    = "plane"
+	KindCube     = "cube"
+	KindCylinder = "cylinder"
+	KindCone     = "cone"
+	KindTriangle = "triangle"
+	KindGroup    = "group"
+	KindCSG      = "csg"
+	KindOBJ      = "obj"
+)
+
+// Pattern types.
+const (
+	PatternStripes  = "stripes"
+	PatternGradient = "gradient"
+	PatternRings    = "rings"
+	PatternCheckers = "checkers"
+)
+
+// CSG operations.
+const (
+	OperationUnion        = "union"
+	OperationIntersection = "intersection"
+	OperationDifference   = "difference"
+)
+
+var (
+	ErrInvalidScene       = errors.New("invalid scene")
+	ErrUnknownSceneFormat = errors.New("unknown scene format")
+)
+
+// transformArgs is the number of arguments of each transform operation.
+var transformArgs = map[string]int{
+	OpTranslate: 3,
+	OpScale:     3,
+	OpRotateX:   1,
+	OpRotateY:   1,
+	OpRotateZ:   1,
+	OpShear:     6,
+}
+
+// Keys of the scene formats.
+var (
+	cameraKeys      = []string{"width", "height", "field-of-view", "from", "to", "up"}
+	lightKeys       = []string{"at", "intensity"}
+	shapeCommonKeys = []string{"transform", "material", "shadow"}
+	materialKeys    = []string{"color", "pattern", "ambient", "diffuse", "specular", "shininess", "reflective", "transparency", "refractive-index"}
+	patternKeys     = []string{"type", "colors", "transform"}
+)
+
+// shapeKeys are the keys of each shape kind, besides shapeCommonKeys and the
+// kind itself.
+var shapeKeys = map[string][]string{
+	KindSphere:   nil,
+	KindPlane:    nil,
+	KindCube:     nil,
+	KindCylinder: {"min", "max", "closed"},
+	KindCone:     {"min", "max", "closed"},
+	KindTriangle: {"p1", "p2", "p3"},
+	KindGroup:    {"children"},
+	KindCSG:      {"operation", "left", "right"},
+	KindOBJ:      {"file"},
+}
+
+// materialRanges are the valid values of the material fields, by key.
+var materialRanges = map[string][2]float64{
+	"ambient":          {0, 1},
+	"diffuse":          {0, 1},
+	"specular":         {0, 1},
+	"shininess":        {0, math.Inf(1)},
+	"reflective":       {0, 1},
+	"transparency":     {0, 1},
+	"refractive-index": {1, math.Inf(1)},
+}
+
+// checkMaterialRange returns an error if v is out of the valid values of the
+// material field key.
+func checkMaterialRange(key string, v float64) error {
+	r := materialRanges[key]
+	if v >= r[0] && v <= r[1] {
+		return nil
+	}
+	if math.IsInf(r[1], 1) {
+		return fmt.Errorf("%s must be at least %g but got %g", key, r[0], v)
+	}
+
+	return fmt.Errorf("%s must be between %g and %g but got %g", key, r[0], r[1], v)
+}
+
+// checkSize returns an error if the camera size key isn't positive.
+func checkSize(key string, v int) error {
+	if v > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%s must be positive but got %d", key, v)
+}
+
+// checkFieldOfView returns an error if the camera field of view isn't
+// between 0 and π radians.
+func checkFieldOfView(v float64) error {
+	if v > 0 && v < math.Pi {
+		return nil
+	}
+
+	return fmt.Errorf("field-of-view must be between 0 and π radians but got %g", v)
+}
+
+// checkBounds returns an error if the minimum of a cylinder or a cone is
+// above its maximum.
+func checkBounds(min, max float64) error {
+	if min <= max {
+		return nil
+	}
+
+	return fmt.Errorf("min must be at most max but got %g and %g", min, max)
+}
+
+// checkColor returns an error if any channel of c is negative.
+func checkColor(c feature.Color) error {
+	for _, ch := range [3]struct {
+		name  string
+		value float64
+	}{{"r", c.R}, {"g", c.G}, {"b", c.B}} {
+		if !(ch.value >= 0) {
+			return fmt.Errorf("%s must be at least 0 but got %g", ch.name, ch.value)
+		}
+	}
+
+	return nil
+}
+
+// Scene describes everything needed to render an image: the camera, the
+// lights and the shapes.
+type Scene struct {
+	Camera *Camera
+	Lights []Light
+	Shapes []Shape
+}
+
+// Load reads a Scene from the YAML or JSON file at path, following the path
+// extension.
+func Load(path string) (*Scene, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return LoadYAML(path)
+	case ".json":
+		return LoadJSON(path)
+	}
+
+	return nil, ErrUnknownSceneFormat
+}
+
+// Camera describes where the scene is seen from.
+type Camera struct {
+	Width  int
+	Height int
+	// FieldOfView is the horizontal angle the camera sees, in radians.
+	FieldOfView float64
+	From        feature.Point
+	To          feature.Point
+	Up          feature.Vector
+}
+
+// Light is a point light.
+type Light struct {
+	At        feature.Point
+	Intensity feature.Color
+}
+
+// Transform is one operation of a list of transformations, like a
+// translation with its x, y and z arguments.
+type Transform struct {
+	Op   string
+	Args []float64
+}
+
+// Transforms is a list of transformations, applied in order.
+type Transforms []Transform
+
+// Matrix returns the Matrix that applies every transformation in order.
+func (ts Transforms) Matrix() feature.Matrix {
+	m := feature.IdentityMatrix
+	for _, t := range ts {
+		m = t.Matrix().Mul(m)
+	}
+
+	return m
+}
+
+// Matrix returns the Matrix of the transformation, or the identity for an
+// unknown operation.
+func (t Transform) Matrix() feature.Matrix {
+	if len(t.Args) != transformArgs[t.Op] {
+		return feature.IdentityMatrix
+	}
+
+	a := t.Args
+	switch t.Op {
+	case OpTranslate:
+		return feature.Translation(a[0], a[1], a[2])
+	case OpScale:
+		return feature.Scaling(a[0], a[1], a[2])
+	case OpRotateX:
+		return feature.RotationX(a[0])
+	case OpRotateY:
+		return feature.RotationY(a[0])
+	case OpRotateZ:
+		return feature.RotationZ(a[0])
+	case OpShear:
+		return feature.Shearing(a[0], a[1], a[2], a[3], a[4], a[5])
+	}
+
+	return feature.IdentityMatrix
+}
+
+// Pattern describes how the color changes over a surface, alternating
+// between its colors.
+type Pattern struct {
+	Type      string
+	Colors    []feature.Color
+	Transform Transforms
+}
+
+// Material describes how a surface reflects the light.
+type Material struct {
+	Color           feature.Color
+	Pattern         *Pattern
+	Ambient         float64
+	Diffuse         float64
+	Specular        float64
+	Shininess       float64
+	Reflective      float64
+	Transparency    float64
+	RefractiveIndex float64
+}
+
+// DefaultMaterial returns the material of the shapes that don't set one.
+func DefaultMaterial() Material {
+	return Material{
+		Color:           feature.RGB(1, 1, 1),
+		Ambient:         0.1,
+		Diffuse:         0.9,
+		Specular:        0.9,
+		Shininess:       200,
+		RefractiveIndex: 1,
+	}
+}
+
+// Shape describes an object of the scene. Only the fields of its kind are
+// used.
+type Shape struct {
+	Kind      string
+	Transform Transforms
+	Material  Material
+	// Shadow tells whether the shape casts shadows.
+	Shadow bool
+
+	// Minimum, Maximum and Closed truncate and cap cylinders and cones.
+	Minimum float64
+	Maximum float64
+	Closed  bool
+
+	// P1, P2 and P3 are the corners of a triangle.
+	P1 feature.Point
+	P2 feature.Point
+	P3 feature.Point
+
+	// Children are the shapes of a group.
+	Children []Shape
+
+	// Operation combines the Left and Right shapes of a CSG.
+	Operation string
+	Left      *Shape
+	Right     *Shape
+
+	// File is the path of an OBJ file.
+	File string
+}
+
+// NewShape creates a new Shape of kind with the default values.
+func NewShape(kind string) Shape {
+	return Shape{
+		Kind:     kind,
+		Material: DefaultMaterial(),
+		Shadow:   true,
+		Minimum:  math.Inf(-1),
+		Maximum:  math.Inf(1),
+	}
+}
@@ -0,0 +1,58 @@
+package scene_test
+
+import (
+	"math"
+	"ray-tracer/feature"
+	"ray-tracer/scene"
+	"testing"
+)
+
+func TestTransformsMatrix(t *testing.T) {
+	tests := []struct {
+		name       string
+		transforms scene.Transforms
+		want       feature.Matrix
+	}{
+		{
+			name:       "empty",
+			transforms: nil,
+			want:       feature.IdentityMatrix,
+		},
+		{
+			name: "applied in order",
+			transforms: scene.Transforms{
+				{Op: scene.OpRotateX, Args: []float64{math.Pi / 2}},
+				{Op: scene.OpScale, Args: []float64{5, 5, 5}},
+				{Op: scene.OpTranslate, Args: []float64{10, 5, 7}},
+			},
+			want: feature.Translation(10, 5, 7).Mul(feature.Scaling(5, 5, 5)).Mul(feature.RotationX(math.Pi / 2)),
+		},
+		{
+			name: "every operation",
+			transforms: scene.Transforms{
+				{Op: scene.OpRotateY, Args: []float64{1}},
+				{Op: scene.OpRotateZ, Args: []float64{2}},
+				{Op: scene.OpShear, Args: []float64{1, 2, 3, 4, 5, 6}},
+			},
+			want: feature.Shearing(1, 2, 3, 4, 5, 6).Mul(feature.RotationZ(2)).Mul(feature.RotationY(1)),
+		},
+		{
+			name: "invalid arguments are ignored",
+			transforms: scene.Transforms{
+				{Op: scene.OpTranslate, Args: []float64{1}},
+				{Op: "spin", Args: nil},
+			},
+			want: feature.IdentityMatrix,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.transforms.Matrix()
+
+			if !test.want.IsEqual(got) {
+				t.Errorf("%q: wants %v and got %v", test.name, test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,730 @@
+package scene
+
+import (
+	"fmt"
+	"os"
+	"ray-tracer/feature"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxYAMLItems is the largest number of shapes and transformations a YAML
+// scene can expand to. Aliases and defines can be reused many times each, so
+// a small file could otherwise expand to millions of shapes.
+const maxYAMLItems = 100000
+
+// LoadYAML reads a Scene from the YAML file at path.
+func LoadYAML(path string) (*Scene, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := ParseYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// ParseYAML reads a Scene from the YAML format of the book bonus scenes: a
+// list of items that either add a camera, a light or a shape, or define a
+// material, a list of transformations or a shape to reuse by name. A define
+// may extend an earlier one, overriding some of its keys.
+// The errors wrap ErrInvalidScene and include the line of the problem.
+func ParseYAML(data []byte) (*Scene, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidScene, err)
+	}
+
+	s := &Scene{}
+	if len(doc.Content) == 0 {
+		return s, nil
+	}
+
+	root := deref(doc.Content[0])
+	if root.Kind != yaml.SequenceNode {
+		return nil, yamlErrorf(root, "expected a list of items")
+	}
+
+	p := yamlParser{
+		defines:   map[string]*yaml.Node{},
+		resolving: map[string]bool{},
+	}
+	for _, item := range root.Content {
+		fields, err := yamlFields(item)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case fields["define"] != nil:
+			err = p.define(item, fields)
+		case fields["add"] != nil:
+			err = p.add(s, item, fields)
+		default:
+			err = yamlErrorf(item, "expected an add or a define item")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// yamlParser holds the defines read so far.
+type yamlParser struct {
+	defines map[string]*yaml.Node
+	// resolving are the defines being expanded, to reject the ones that
+	// refer to themselves.
+	resolving map[string]bool
+	// items is the number of shapes and transformations read so far.
+	items int
+}
+
+// expand counts a shape or a transformation read from n, returning an error
+// past maxYAMLItems.
+func (p *yamlParser) expand(n *yaml.Node) error {
+	p.items++
+	if p.items > maxYAMLItems {
+		return yamlErrorf(n, "the scene expands to more than %d shapes and transformations", maxYAMLItems)
+	}
+
+	return nil
+}
+
+func (p *yamlParser) define(n *yaml.Node, fields map[string]*yaml.Node) error {
+	if err := yamlKeys(n, "define", "extend", "value"); err != nil {
+		return err
+	}
+
+	name, err := yamlString(fields["define"])
+	if err != nil {
+		return err
+	}
+	value := fields["value"]
+	if value == nil {
+		return yamlErrorf(n, "define %q without a value", name)
+	}
+	value = deref(value)
+
+	if extend := fields["extend"]; extend != nil {
+		base, err := p.lookup(extend)
+		if err != nil {
+			return err
+		}
+		if base.Kind != yaml.MappingNode || value.Kind != yaml.MappingNode {
+			return yamlErrorf(extend, "only maps can be extended")
+		}
+		value = mergeMappings(base, value)
+	}
+
+	p.defines[name] = value
+
+	return nil
+}
+
+// lookup returns the value of the define named by the scalar n.
+func (p *yamlParser) lookup(n *yaml.Node) (*yaml.Node, error) {
+	name, err := yamlString(n)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := p.defines[name]
+	if !ok {
+		return nil, yamlErrorf(n, "unknown define %q", name)
+	}
+
+	return value, nil
+}
+
+func (p *yamlParser) add(s *Scene, n *yaml.Node, fields map[string]*yaml.Node) error {
+	kind, err := yamlString(fields["add"])
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case "camera":
+		if s.Camera != nil {
+			return yamlErrorf(n, "more than one camera")
+		}
+		c, err := p.camera(n, fields)
+		if err != nil {
+			return err
+		}
+		s.Camera = &c
+	case "light":
+		l, err := p.light(n, fields)
+		if err != nil {
+			return err
+		}
+		s.Lights = append(s.Lights, l)
+	default:
+		shape, err := p.shape(n)
+		if err != nil {
+			return err
+		}
+		s.Shapes = append(s.Shapes, shape)
+	}
+
+	return nil
+}
+
+func (p *yamlParser) camera(n *yaml.Node, fields map[string]*yaml.Node) (Camera, error) {
+	var c Camera
+
+	if err := yamlKeys(n, append([]string{"add"}, cameraKeys...)...); err != nil {
+		return c, err
+	}
+	if err := yamlRequired(n, fields, cameraKeys...); err != nil {
+		return c, err
+	}
+
+	var err error
+	if c.Width, err = yamlInt(fields["width"]); err != nil {
+		return c, err
+	}
+	if err := checkSize("width", c.Width); err != nil {
+		return c, yamlErrorf(fields["width"], "%v", err)
+	}
+	if c.Height, err = yamlInt(fields["height"]); err != nil {
+		return c, err
+	}
+	if err := checkSize("height", c.Height); err != nil {
+		return c, yamlErrorf(fields["height"], "%v", err)
+	}
+	if c.FieldOfView, err = yamlFloat(fields["field-of-view"]); err != nil {
+		return c, err
+	}
+	if err := checkFieldOfView(c.FieldOfView); err != nil {
+		return c, yamlErrorf(fields["field-of-view"], "%v", err)
+	}
+	if c.From, err = yamlPoint(fields["from"]); err != nil {
+		return c, err
+	}
+	if c.To, err = yamlPoint(fields["to"]); err != nil {
+		return c, err
+	}
+	if c.Up, err = yamlVector(fields["up"]); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+func (p *yamlParser) light(n *yaml.Node, fields map[string]*yaml.Node) (Light, error) {
+	l := Light{Intensity: feature.RGB(1, 1, 1)}
+
+	if err := yamlKeys(n, append([]string{"add"}, lightKeys...)...); err != nil {
+		return l, err
+	}
+	if err := yamlRequired(n, fields, "at"); err != nil {
+		return l, err
+	}
+
+	var err error
+	if l.At, err = yamlPoint(fields["at"]); err != nil {
+		return l, err
+	}
+	if v := fields["intensity"]; v != nil {
+		if l.Intensity, err = yamlColor(v); err != nil {
+			return l, err
+		}
+	}
+
+	return l, nil
+}
+
+func (p *yamlParser) shape(n *yaml.Node) (Shape, error) {
+	if err := p.expand(n); err != nil {
+		return Shape{}, err
+	}
+
+	fields, err := yamlFields(n)
+	if err != nil {
+		return Shape{}, err
+	}
+	if fields["add"] == nil {
+		return Shape{}, yamlErrorf(n, "shape without an add key")
+	}
+	kind, err := yamlString(fields["add"])
+	if err != nil {
+		return Shape{}, err
+	}
+
+	keys, ok := shapeKeys[kind]
+	if !ok {
+		// A defined shape, where the keys of n override the ones of the
+		// define.
+		if p.resolving[kind] {
+			return Shape{}, yamlErrorf(n, "define %q refers to itself", kind)
+		}
+		value, err := p.lookup(fields["add"])
+		if err != nil {
+			return Shape{}, yamlErrorf(n, "unknown shape %q", kind)
+		}
+		if value.Kind != yaml.MappingNode {
+			return Shape{}, yamlErrorf(fields["add"], "define %q isn't a shape", kind)
+		}
+
+		p.resolving[kind] = true
+		defer delete(p.resolving, kind)
+
+		return p.shape(mergeMappings(value, withoutKey(n, "add")))
+	}
+
+	if err := yamlKeys(n, append(append([]string{"add"}, shapeCommonKeys...), keys...)...); err != nil {
+		return Shape{}, err
+	}
+
+	s := NewShape(kind)
+	if v := fields["transform"]; v != nil {
+		if s.Transform, err = p.transforms(v); err != nil {
+			return s, err
+		}
+	}
+	if v := fields["material"]; v != nil {
+		if s.Material, err = p.material(v); err != nil {
+			return s, err
+		}
+	}
+	if v := fields["shadow"]; v != nil {
+		if s.Shadow, err = yamlBool(v); err != nil {
+			return s, err
+		}
+	}
+
+	switch kind {
+	case KindCylinder, KindCone:
+		if v := fields["min"]; v != nil {
+			if s.Minimum, err = yamlFloat(v); err != nil {
+				return s, err
+			}
+		}
+		if v := fields["max"]; v != nil {
+			if s.Maximum, err = yamlFloat(v); err != nil {
+				return s, err
+			}
+		}
+		if err := checkBounds(s.Minimum, s.Maximum); err != nil {
+			return s, yamlErrorf(n, "%v", err)
+		}
+		if v := fields["closed"]; v != nil {
+			if s.Closed, err = yamlBool(v); err != nil {
+				return s, err
+			}
+		}
+	case KindTriangle:
+		if err := yamlRequired(n, fields, "p1", "p2", "p3"); err != nil {
+			return s, err
+		}
+		for _, c := range []struct {
+			key string
+			p   *feature.Point
+		}{{"p1", &s.P1}, {"p2", &s.P2}, {"p3", &s.P3}} {
+			if *c.p, err = yamlPoint(fields[c.key]); err != nil {
+				return s, err
+			}
+		}
+	case KindGroup:
+		if v := fields["children"]; v != nil {
+			v = deref(v)
+			if v.Kind != yaml.SequenceNode {
+				return s, yamlErrorf(v, "expected a list of shapes")
+			}
+			for _, child := range v.Content {
+				c, err := p.shape(deref(child))
+				if err != nil {
+					return s, err
+				}
+				s.Children = append(s.Children, c)
+			}
+		}
+	case KindCSG:
+		if err := yamlRequired(n, fields, "operation", "left", "right"); err != nil {
+			return s, err
+		}
+		if s.Operation, err = yamlString(fields["operation"]); err != nil {
+			return s, err
+		}
+		if !slices.Contains([]string{OperationUnion, OperationIntersection, OperationDifference}, s.Operation) {
+			return s, yamlErrorf(fields["operation"], "unknown CSG operation %q", s.Operation)
+		}
+		left, err := p.shape(deref(fields["left"]))
+		if err != nil {
+			return s, err
+		}
+		right, err := p.shape(deref(fields["right"]))
+		if err != nil {
+			return s, err
+		}
+		s.Left, s.Right = &left, &right
+	case KindOBJ:
+		if err := yamlRequired(n, fields, "file"); err != nil {
+			return s, err
+		}
+		if s.File, err = yamlString(fields["file"]); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
+
+// transforms reads a list of transformations, where each item is either an
+// operation with its arguments, like [translate, 1, 2, 3], or the name of a
+// defined list. The whole list may be a name too.
+func (p *yamlParser) transforms(n *yaml.Node) (Transforms, error) {
+	n = deref(n)
+	if n.Kind == yaml.ScalarNode {
+		return p.definedTransforms(n)
+	}
+	if n.Kind != yaml.SequenceNode {
+		return nil, yamlErrorf(n, "expected a list of transformations")
+	}
+
+	var ts Transforms
+	for _, item := range n.Content {
+		item = deref(item)
+		if err := p.expand(item); err != nil {
+			return nil, err
+		}
+		if item.Kind == yaml.ScalarNode {
+			defined, err := p.definedTransforms(item)
+			if err != nil {
+				return nil, err
+			}
+			ts = append(ts, defined...)
+			continue
+		}
+
+		if item.Kind != yaml.SequenceNode || len(item.Content) == 0 {
+			return nil, yamlErrorf(item, "expected a transformation like [translate, 1, 2, 3]")
+		}
+		op, err := yamlString(item.Content[0])
+		if err != nil {
+			return nil, err
+		}
+		args, ok := transformArgs[op]
+		if !ok {
+			return nil, yamlErrorf(item, "unknown transformation %q", op)
+		}
+		if len(item.Content)-1 != args {
+			return nil, yamlErrorf(item, "%s expects %d arguments but got %d", op, args, len(item.Content)-1)
+		}
+
+		t := Transform{Op: op, Args: make([]float64, args)}
+		for i, arg := range item.Content[1:] {
+			if t.Args[i], err = yamlFloat(arg); err != nil {
+				return nil, err
+			}
+		}
+		ts = append(ts, t)
+	}
+
+	return ts, nil
+}
+
+func (p *yamlParser) definedTransforms(n *yaml.Node) (Transforms, error) {
+	name := n.Value
+	if p.resolving[name] {
+		return nil, yamlErrorf(n, "define %q refers to itself", name)
+	}
+	value, err := p.lookup(n)
+	if err != nil {
+		return nil, err
+	}
+	if value.Kind != yaml.SequenceNode {
+		return nil, yamlErrorf(n, "define %q isn't a list of transformations", name)
+	}
+
+	p.resolving[name] = true
+	defer delete(p.resolving, name)
+
+	return p.transforms(value)
+}
+
+// material reads a material map, or the name of a defined one, over the
+// default material.
+func (p *yamlParser) material(n *yaml.Node) (Material, error) {
+	m := DefaultMaterial()
+
+	n = deref(n)
+	if n.Kind == yaml.ScalarNode {
+		value, err := p.lookup(n)
+		if err != nil {
+			return m, err
+		}
+		n = value
+	}
+
+	fields, err := yamlFields(n)
+	if err != nil {
+		return m, err
+	}
+	if err := yamlKeys(n, materialKeys...); err != nil {
+		return m, err
+	}
+
+	if v := fields["color"]; v != nil {
+		if m.Color, err = yamlColor(v); err != nil {
+			return m, err
+		}
+	}
+	if v := fields["pattern"]; v != nil {
+		pattern, err := p.pattern(v)
+		if err != nil {
+			return m, err
+		}
+		m.Pattern = &pattern
+	}
+
+	for _, f := range []struct {
+		key   string
+		value *float64
+	}{
+		{"ambient", &m.Ambient},
+		{"diffuse", &m.Diffuse},
+		{"specular", &m.Specular},
+		{"shininess", &m.Shininess},
+		{"reflective", &m.Reflective},
+		{"transparency", &m.Transparency},
+		{"refractive-index", &m.RefractiveIndex},
+	} {
+		if v := fields[f.key]; v != nil {
+			if *f.value, err = yamlFloat(v); err != nil {
+				return m, err
+			}
+			if err := checkMaterialRange(f.key, *f.value); err != nil {
+				return m, yamlErrorf(v, "%v", err)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (p *yamlParser) pattern(n *yaml.Node) (Pattern, error) {
+	var pattern Pattern
+
+	n = deref(n)
+	fields, err := yamlFields(n)
+	if err != nil {
+		return pattern, err
+	}
+	if err := yamlKeys(n, patternKeys...); err != nil {
+		return pattern, err
+	}
+	if err := yamlRequired(n, fields, "type", "colors"); err != nil {
+		return pattern, err
+	}
+
+	if pattern.Type, err = yamlString(fields["type"]); err != nil {
+		return pattern, err
+	}
+	if !slices.Contains([]string{PatternStripes, PatternGradient, PatternRings, PatternCheckers}, pattern.Type) {
+		return pattern, yamlErrorf(fields["type"], "unknown pattern %q", pattern.Type)
+	}
+
+	colors := deref(fields["colors"])
+	if colors.Kind != yaml.SequenceNode || len(colors.Content) != 2 {
+		return pattern, yamlErrorf(colors, "expected a list of two colors")
+	}
+	for _, c := range colors.Content {
+		color, err := yamlColor(c)
+		if err != nil {
+			return pattern, err
+		}
+		pattern.Colors = append(pattern.Colors, color)
+	}
+
+	if v := fields["transform"]; v != nil {
+		if pattern.Transform, err = p.transforms(v); err != nil {
+			return pattern, err
+		}
+	}
+
+	return pattern, nil
+}
+
+// yamlErrorf returns an ErrInvalidScene error at the line of n.
+func yamlErrorf(n *yaml.Node, format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidScene, n.Line, fmt.Sprintf(format, args...))
+}
+
+// deref returns the node an alias points to.
+func deref(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	return n
+}
+
+// yamlFields returns the values of the map n by key.
+func yamlFields(n *yaml.Node) (map[string]*yaml.Node, error) {
+	n = deref(n)
+	if n.Kind != yaml.MappingNode {
+		return nil, yamlErrorf(n, "expected a map")
+	}
+
+	fields := make(map[string]*yaml.Node, len(n.Content)/2)
+	for i := 0; i < len(n.Content); i += 2 {
+		key := n.Content[i].Value
+		if _, ok := fields[key]; ok {
+			return nil, yamlErrorf(n.Content[i], "duplicated key %q", key)
+		}
+		fields[key] = n.Content[i+1]
+	}
+
+	return fields, nil
+}
+
+// yamlKeys checks that the map n only has the allowed keys.
+func yamlKeys(n *yaml.Node, allowed ...string) error {
+	for i := 0; i < len(n.Content); i += 2 {
+		key := n.Content[i]
+		if !slices.Contains(allowed, key.Value) {
+			return yamlErrorf(key, "unknown key %q, expected one of %s", key.Value, strings.Join(allowed, ", "))
+		}
+	}
+
+	return nil
+}
+
+// yamlRequired checks that the fields of the map n have the required keys.
+func yamlRequired(n *yaml.Node, fields map[string]*yaml.Node, required ...string) error {
+	for _, key := range required {
+		if fields[key] == nil {
+			return yamlErrorf(n, "missing key %q", key)
+		}
+	}
+
+	return nil
+}
+
+// mergeMappings returns a new map with the keys of base and over, where the
+// values of over win.
+func mergeMappings(base, over *yaml.Node) *yaml.Node {
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: over.Line, Column: over.Column}
+	for i := 0; i < len(base.Content); i += 2 {
+		if !hasKey(over, base.Content[i].Value) {
+			merged.Content = append(merged.Content, base.Content[i], base.Content[i+1])
+		}
+	}
+	merged.Content = append(merged.Content, over.Content...)
+
+	return merged
+}
+
+// withoutKey returns a copy of the map n without key.
+func withoutKey(n *yaml.Node, key string) *yaml.Node {
+	r := *n
+	r.Content = nil
+	for i := 0; i < len(n.Content); i += 2 {
+		if n.Content[i].Value != key {
+			r.Content = append(r.Content, n.Content[i], n.Content[i+1])
+		}
+	}
+
+	return &r
+}
+
+func hasKey(n *yaml.Node, key string) bool {
+	for i := 0; i < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func yamlString(n *yaml.Node) (string, error) {
+	n = deref(n)
+	if n.Kind != yaml.ScalarNode {
+		return "", yamlErrorf(n, "expected a name")
+	}
+
+	return n.Value, nil
+}
+
+func yamlFloat(n *yaml.Node) (float64, error) {
+	var v float64
+	if n = deref(n); n.Kind != yaml.ScalarNode || n.Decode(&v) != nil {
+		return 0, yamlErrorf(n, "expected a number")
+	}
+
+	return v, nil
+}
+
+func yamlInt(n *yaml.Node) (int, error) {
+	var v int
+	if n = deref(n); n.Kind != yaml.ScalarNode || n.Decode(&v) != nil {
+		return 0, yamlErrorf(n, "expected an integer")
+	}
+
+	return v, nil
+}
+
+func yamlBool(n *yaml.Node) (bool, error) {
+	var v bool
+	if n = deref(n); n.Kind != yaml.ScalarNode || n.Decode(&v) != nil {
+		return false, yamlErrorf(n, "expected true or false")
+	}
+
+	return v, nil
+}
+
+// yamlTriple reads a list of three numbers.
+func yamlTriple(n *yaml.Node) ([3]float64, error) {
+	var v [3]float64
+
+	n = deref(n)
+	if n.Kind != yaml.SequenceNode || len(n.Content) != 3 {
+		return v, yamlErrorf(n, "expected a list of three numbers")
+	}
+	for i, c := range n.Content {
+		f, err := yamlFloat(c)
+		if err != nil {
+			return v, err
+		}
+		v[i] = f
+	}
+
+	return v, nil
+}
+
+func yamlPoint(n *yaml.Node) (feature.Point, error) {
+	v, err := yamlTriple(n)
+
+	return feature.Pt(v[0], v[1], v[2]), err
+}
+
+func yamlVector(n *yaml.Node) (feature.Vector, error) {
+	v, err := yamlTriple(n)
+
+	return feature.Vec(v[0], v[1], v[2]), err
+}
+
+// yamlColor reads a color, which can't have negative channels.
+func yamlColor(n *yaml.Node) (feature.Color, error) {
+	v, err := yamlTriple(n)
+	if err != nil {
+		return feature.Color{}, err
+	}
+
+	c := feature.RGB(v[0], v[1], v[2])
+	if err := checkColor(c); err != nil {
+		return c, yamlErrorf(n, "%v", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,338 @@
+package scene_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"ray-tracer/feature"
+	"ray-tracer/scene"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bookScene = `
+- add: camera
+  width: 100
+  height: 50
+  field-of-view: 0.785
+  from: [ -6, 6, -10 ]
+  to: [ 6, 0, 6 ]
+  up: [ -0.45, 1, 0 ]
+
+- add: light
+  at: [ 50, 100, -50 ]
+  intensity: [ 1, 1, 1 ]
+
+- add: light
+  at: [ -400, 50, -10 ]
+  intensity: [ 0.2, 0.2, 0.2 ]
+
+- define: white-material
+  value:
+    color: [ 1, 1, 1 ]
+    diffuse: 0.7
+    ambient: 0.1
+    specular: 0.0
+    reflective: 0.1
+
+- define: blue-material
+  extend: white-material
+  value:
+    color: [ 0.537, 0.831, 0.914 ]
+
+- define: standard-transform
+  value:
+    - [ translate, 1, -1, 1 ]
+    - [ scale, 0.5, 0.5, 0.5 ]
+
+- define: large-object
+  value:
+    - standard-transform
+    - [ scale, 3.5, 3.5, 3.5 ]
+
+- define: blue-cube
+  value:
+    add: cube
+    material: blue-material
+    shadow: false
+
+- add: plane
+  material: white-material
+  transform:
+    - [ rotate-x, 1.5707963267948966 ]
+    - [ translate, 0, 0, 500 ]
+
+- add: blue-cube
+  transform: large-object
+
+- add: group
+  children:
+    - add: cylinder
+      min: 0
+      max: 1
+      closed: true
+      material:
+        pattern:
+          type: stripes
+          colors: [ [ 1, 1, 1 ], [ 0, 0, 0 ] ]
+          transform:
+            - [ scale, 0.25, 0.25, 0.25 ]
+    - add: triangle
+      p1: [ 0, 1, 0 ]
+      p2: [ -1, 0, 0 ]
+      p3: [ 1, 0, 0 ]
+
+- add: csg
+  operation: difference
+  left:
+    add: sphere
+  right:
+    add: cone
+`
+
+func TestParseYAML(t *testing.T) {
+	got, err := scene.ParseYAML([]byte(bookScene))
+	if err != nil {
+		t.Fatalf("error parsing the scene: %v", err)
+	}
+
+	white := scene.DefaultMaterial()
+	white.Diffuse = 0.7
+	white.Specular = 0
+	white.Reflective = 0.1
+	blue := white
+	blue.Color = feature.RGB(0.537, 0.831, 0.914)
+
+	plane := scene.NewShape(scene.KindPlane)
+	plane.Material = white
+	plane.Transform = scene.Transforms{
+		{Op: scene.OpRotateX, Args: []float64{math.Pi / 2}},
+		{Op: scene.OpTranslate, Args: []float64{0, 0, 500}},
+	}
+
+	cube := scene.NewShape(scene.KindCube)
+	cube.Material = blue
+	cube.Shadow = false
+	cube.Transform = scene.Transforms{
+		{Op: scene.OpTranslate, Args: []float64{1, -1, 1}},
+		{Op: scene.OpScale, Args: []float64{0.5, 0.5, 0.5}},
+		{Op: scene.OpScale, Args: []float64{3.5, 3.5, 3.5}},
+	}
+
+	cylinder := scene.NewShape(scene.KindCylinder)
+	cylinder.Minimum, cylinder.Maximum, cylinder.Closed = 0, 1, true
+	cylinder.Material.Pattern = &scene.Pattern{
+		Type:      scene.PatternStripes,
+		Colors:    []feature.Color{feature.RGB(1, 1, 1), feature.RGB(0, 0, 0)},
+		Transform: scene.Transforms{{Op: scene.OpScale, Args: []float64{0.25, 0.25, 0.25}}},
+	}
+	triangle := scene.NewShape(scene.KindTriangle)
+	triangle.P1, triangle.P2, triangle.P3 = feature.Pt(0, 1, 0), feature.Pt(-1, 0, 0), feature.Pt(1, 0, 0)
+	group := scene.NewShape(scene.KindGroup)
+	group.Children = []scene.Shape{cylinder, triangle}
+
+	sphere, cone := scene.NewShape(scene.KindSphere), scene.NewShape(scene.KindCone)
+	csg := scene.NewShape(scene.KindCSG)
+	csg.Operation, csg.Left, csg.Right = scene.OperationDifference, &sphere, &cone
+
+	want := &scene.Scene{
+		Camera: &scene.Camera{
+			Width:       100,
+			Height:      50,
+			FieldOfView: 0.785,
+			From:        feature.Pt(-6, 6, -10),
+			To:          feature.Pt(6, 0, 6),
+			Up:          feature.Vec(-0.45, 1, 0),
+		},
+		Lights: []scene.Light{
+			{At: feature.Pt(50, 100, -50), Intensity: feature.RGB(1, 1, 1)},
+			{At: feature.Pt(-400, 50, -10), Intensity: feature.RGB(0.2, 0.2, 0.2)},
+		},
+		Shapes: []scene.Shape{plane, cube, group, csg},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wants\n%+v\nand got\n%+v", want, got)
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		err  string
+	}{
+		{
+			name: "not a list",
+			yaml: "add: sphere",
+			err:  "line 1: expected a list of items",
+		},
+		{
+			name: "invalid syntax",
+			yaml: "- add: [sphere",
+			err:  "yaml:",
+		},
+		{
+			name: "neither add nor define",
+			yaml: "- remove: sphere",
+			err:  "line 1: expected an add or a define item",
+		},
+		{
+			name: "unknown shape",
+			yaml: "- add: teapot",
+			err:  `line 1: unknown shape "teapot"`,
+		},
+		{
+			name: "unknown key",
+			yaml: "- add: sphere\n  radius: 2",
+			err:  `line 2: unknown key "radius"`,
+		},
+		{
+			name: "key of another kind",
+			yaml: "- add: sphere\n  closed: true",
+			err:  `line 2: unknown key "closed"`,
+		},
+		{
+			name: "missing camera key",
+			yaml: "- add: camera\n  width: 10\n  height: 10",
+			err:  `line 1: missing key "field-of-view"`,
+		},
+		{
+			name: "two cameras",
+			yaml: "- add: camera\n  width: 1\n  height: 1\n  field-of-view: 1\n  from: [0, 0, 0]\n  to: [0, 0, 1]\n  up: [0, 1, 0]\n" +
+				"- add: camera\n  width: 1\n  height: 1\n  field-of-view: 1\n  from: [0, 0, 0]\n  to: [0, 0, 1]\n  up: [0, 1, 0]",
+			err: "line 8: more than one camera",
+		},
+		{
+			name: "invalid number",
+			yaml: "- add: light\n  at: [0, one, 0]",
+			err:  "line 2: expected a number",
+		},
+		{
+			name: "short tuple",
+			yaml: "- add: light\n  at: [0, 1]",
+			err:  "line 2: expected a list of three numbers",
+		},
+		{
+			name: "unknown transformation",
+			yaml: "- add: sphere\n  transform:\n    - [ spin, 1 ]",
+			err:  `line 3: unknown transformation "spin"`,
+		},
+		{
+			name: "wrong number of arguments",
+			yaml: "- add: sphere\n  transform:\n    - [ translate, 1, 2 ]",
+			err:  "line 3: translate expects 3 arguments but got 2",
+		},
+		{
+			name: "unknown define",
+			yaml: "- add: sphere\n  material: gold",
+			err:  `line 2: unknown define "gold"`,
+		},
+		{
+			name: "extending a list",
+			yaml: "- define: t\n  value:\n    - [ scale, 1, 1, 1 ]\n- define: u\n  extend: t\n  value:\n    - [ scale, 2, 2, 2 ]",
+			err:  "line 5: only maps can be extended",
+		},
+		{
+			name: "define referring to itself",
+			yaml: "- define: t\n  value:\n    - t\n- add: sphere\n  transform: t",
+			err:  `define "t" refers to itself`,
+		},
+		{
+			name: "material value out of range",
+			yaml: "- add: sphere\n  material:\n    ambient: 1.5",
+			err:  "line 3: ambient must be between 0 and 1 but got 1.5",
+		},
+		{
+			name: "camera size",
+			yaml: "- add: camera\n  width: 0\n  height: 1\n  field-of-view: 1\n  from: [0, 0, 0]\n  to: [0, 0, 1]\n  up: [0, 1, 0]",
+			err:  "line 2: width must be positive but got 0",
+		},
+		{
+			name: "field of view out of range",
+			yaml: "- add: camera\n  width: 1\n  height: 1\n  field-of-view: 3.5\n  from: [0, 0, 0]\n  to: [0, 0, 1]\n  up: [0, 1, 0]",
+			err:  "line 4: field-of-view must be between 0 and π radians but got 3.5",
+		},
+		{
+			name: "negative color",
+			yaml: "- add: light\n  at: [0, 0, 0]\n  intensity: [-1, 1, 1]",
+			err:  "line 3: r must be at least 0 but got -1",
+		},
+		{
+			name: "cylinder bounds",
+			yaml: "- add: cylinder\n  min: 2\n  max: 1",
+			err:  "line 1: min must be at most max but got 2 and 1",
+		},
+		{
+			name: "unknown pattern",
+			yaml: "- add: sphere\n  material:\n    pattern:\n      type: waves\n      colors: [[1, 1, 1], [0, 0, 0]]",
+			err:  `line 4: unknown pattern "waves"`,
+		},
+		{
+			name: "unknown CSG operation",
+			yaml: "- add: csg\n  operation: xor\n  left:\n    add: sphere\n  right:\n    add: cube",
+			err:  `line 2: unknown CSG operation "xor"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := scene.ParseYAML([]byte(test.yaml))
+
+			if !errors.Is(err, scene.ErrInvalidScene) || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: got error %v, expected an invalid scene error with %q", test.name, err, test.err)
+			}
+		})
+	}
+}
+
+func TestParseYAMLExpansion(t *testing.T) {
+	// Each level reuses the previous one ten times, for 10^7 shapes or
+	// transformations at the last level.
+	aliases := "- define: l0\n  value: &l0 {add: sphere}\n"
+	defines := "- define: t0\n  value: [[scale, 1, 1, 1]]\n"
+	for i := 1; i <= 7; i++ {
+		aliases += fmt.Sprintf("- define: l%d\n  value: &l%d {add: group, children: [%s]}\n", i, i, strings.Repeat(fmt.Sprintf("*l%d, ", i-1), 10))
+		defines += fmt.Sprintf("- define: t%d\n  value: [%s]\n", i, strings.Repeat(fmt.Sprintf("t%d, ", i-1), 10))
+	}
+
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{name: "aliases", yaml: aliases + "- add: group\n  children: [*l7]\n"},
+		{name: "defines", yaml: defines + "- add: sphere\n  transform: t7\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := scene.ParseYAML([]byte(test.yaml))
+
+			if want := "expands to more than"; !errors.Is(err, scene.ErrInvalidScene) || !strings.Contains(err.Error(), want) {
+				t.Errorf("%q: got error %v, expected an invalid scene error with %q", test.name, err, want)
+			}
+		})
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.yaml")
+	if err := os.WriteFile(path, []byte("- add: sphere\n- add: light\n  at: [0, 0, 0]\n"), 0o600); err != nil {
+		t.Fatalf("error writing the scene: %v", err)
+	}
+
+	got, err := scene.LoadYAML(path)
+	if err != nil {
+		t.Fatalf("error loading the scene: %v", err)
+	}
+	if got.Camera != nil || len(got.Lights) != 1 || len(got.Shapes) != 1 {
+		t.Errorf("wants a light and a shape and got %+v", got)
+	}
+
+	if _, err := scene.LoadYAML(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file but got no error")
+	}
+}